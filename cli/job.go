@@ -1,15 +1,26 @@
 package cli
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
+	"github.com/wolves-fc/tasker/lib/client"
 )
 
 func (c *CLI) jobCmd() *cobra.Command {
@@ -24,16 +35,197 @@ func (c *CLI) jobCmd() *cobra.Command {
 	must(cmd.MarkPersistentFlagRequired("addr"))
 	cmd.AddCommand(c.startJobCmd())
 	cmd.AddCommand(c.stopJobCmd())
+	cmd.AddCommand(c.signalJobCmd())
+	cmd.AddCommand(c.pauseJobCmd())
+	cmd.AddCommand(c.resumeJobCmd())
+	cmd.AddCommand(c.updateJobCmd())
+	cmd.AddCommand(c.statsJobCmd())
 	cmd.AddCommand(c.getJobCmd())
+	cmd.AddCommand(c.listJobsCmd())
 	cmd.AddCommand(c.attachJobCmd())
+	cmd.AddCommand(c.logsJobCmd())
 
 	return cmd
 }
 
+// limitFlags holds the resource limit flags shared by start and update.
+type limitFlags struct {
+	cpu, cpuBurst             float32
+	memory, read, write, pids uint32
+	memoryHigh, memoryLow     uint32
+	memoryMin, swap           uint32
+	cpuWeight                 uint32
+	cpus, mems                string
+	devices                   []string
+	oomGroup                  bool
+}
+
+// register adds the resource limit flags to a command.
+func (f *limitFlags) register(cmd *cobra.Command) {
+	cmd.Flags().Float32VarP(&f.cpu, "cpu", "c", 0, "CPU limit in cores (e.g. 0.5)")
+	cmd.Flags().Float32Var(&f.cpuBurst, "cpu-burst", 0, "Extra CPU in cores borrowed from unused quota (at most -c)")
+	cmd.Flags().Uint32Var(&f.cpuWeight, "cpu-weight", 0, "Relative CPU share against other jobs (1-10000, default 100)")
+	cmd.Flags().StringVar(&f.cpus, "cpus", "", "CPUs to pin the job to (e.g. 0-3,8)")
+	cmd.Flags().StringVar(&f.mems, "mems", "", "NUMA memory nodes to pin the job to (e.g. 0)")
+	cmd.Flags().Uint32VarP(&f.memory, "memory", "m", 0, "Memory limit in MB")
+	cmd.Flags().StringArrayVarP(
+		&f.devices,
+		"device",
+		"d",
+		nil,
+		"Block device IO limits as PATH[,read=,write=,riops=,wiops=,weight=,latency=] (repeatable)",
+	)
+	cmd.Flags().Uint32VarP(&f.read, "read", "r", 0, "IO read limit in MB/s for each -d (requires -d)")
+	cmd.Flags().Uint32VarP(&f.write, "write", "w", 0, "IO write limit in MB/s for each -d (requires -d)")
+	cmd.Flags().Uint32VarP(&f.pids, "pids", "p", 0, "Max number of processes (server default 1000)")
+	cmd.Flags().BoolVar(&f.oomGroup, "oom-group", false, "Kill all of the job's processes together on OOM")
+	cmd.Flags().Uint32Var(&f.memoryHigh, "memory-high", 0, "Memory throttle threshold in MB (at most -m)")
+	cmd.Flags().Uint32Var(&f.memoryLow, "memory-low", 0, "Best effort memory protection in MB")
+	cmd.Flags().Uint32Var(&f.memoryMin, "memory-min", 0, "Hard memory protection in MB")
+	cmd.Flags().Uint32Var(&f.swap, "swap", 0, "Swap limit in MB (0 disables swap)")
+}
+
+// limits builds proto ResourceLimits from the flags that were set (nil if none were).
+func (f *limitFlags) limits(cmd *cobra.Command) (*taskerpb.ResourceLimits, error) {
+	changed := cmd.Flags().Changed
+
+	if (changed("read") || changed("write")) && !changed("device") {
+		return nil, fmt.Errorf("-d is required when -r or -w is set")
+	}
+
+	set := false
+	for _, name := range []string{
+		"cpu", "memory", "device", "pids", "oom-group", "memory-high", "memory-low", "memory-min", "swap",
+		"cpu-weight", "cpu-burst", "cpus", "mems",
+	} {
+		set = set || changed(name)
+	}
+
+	if !set {
+		return nil, nil
+	}
+
+	limits := &taskerpb.ResourceLimits{}
+	if changed("cpu") {
+		limits.Cpu = &f.cpu
+	}
+
+	if changed("cpu-weight") {
+		limits.CpuWeight = &f.cpuWeight
+	}
+
+	if changed("cpu-burst") {
+		limits.CpuBurst = &f.cpuBurst
+	}
+
+	if changed("cpus") {
+		limits.CpusetCpus = &f.cpus
+	}
+
+	if changed("mems") {
+		limits.CpusetMems = &f.mems
+	}
+
+	if changed("memory") {
+		limits.Memory = &f.memory
+	}
+
+	if changed("pids") {
+		limits.Pids = &f.pids
+	}
+
+	if changed("oom-group") {
+		limits.OomGroup = &f.oomGroup
+	}
+
+	if changed("memory-high") {
+		limits.MemoryHigh = &f.memoryHigh
+	}
+
+	if changed("memory-low") {
+		limits.MemoryLow = &f.memoryLow
+	}
+
+	if changed("memory-min") {
+		limits.MemoryMin = &f.memoryMin
+	}
+
+	if changed("swap") {
+		limits.Swap = &f.swap
+	}
+
+	for _, spec := range f.devices {
+		limit, err := parseDevice(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		if limit.Read == nil && changed("read") {
+			limit.Read = &f.read
+		}
+
+		if limit.Write == nil && changed("write") {
+			limit.Write = &f.write
+		}
+
+		limits.Io = append(limits.Io, limit)
+	}
+
+	return limits, nil
+}
+
+// parseDevice parses a -d spec (PATH[,key=value...]) into proto IOLimits.
+func parseDevice(spec string) (*taskerpb.IOLimits, error) {
+	path, options, _ := strings.Cut(spec, ",")
+	if path == "" {
+		return nil, fmt.Errorf("invalid device (device=%s): path is required", spec)
+	}
+
+	limit := &taskerpb.IOLimits{Device: path}
+	if options == "" {
+		return limit, nil
+	}
+
+	for option := range strings.SplitSeq(options, ",") {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid device option (device=%s, option=%s): want key=value", path, option)
+		}
+
+		num, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device option (device=%s, option=%s): %w", path, option, err)
+		}
+
+		n := uint32(num)
+		switch key {
+		case "read":
+			limit.Read = &n
+		case "write":
+			limit.Write = &n
+		case "riops":
+			limit.ReadIops = &n
+		case "wiops":
+			limit.WriteIops = &n
+		case "weight":
+			limit.Weight = &n
+		case "latency":
+			limit.Latency = &n
+		default:
+			return nil, fmt.Errorf("unknown device option (device=%s, option=%s)", path, key)
+		}
+	}
+
+	return limit, nil
+}
+
 func (c *CLI) startJobCmd() *cobra.Command {
-	var cpu float32
-	var memory, read, write uint32
-	var device string
+	var lf limitFlags
+	var envBase, envFile, workdir string
+	var stdin, tty bool
+	var envVars []string
+	var timeout, stopGrace time.Duration
+	var stopSignal string
 
 	cmd := &cobra.Command{
 		Use:   "start [flags] <command> [args...]",
@@ -42,40 +234,67 @@ func (c *CLI) startJobCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			changed := cmd.Flags().Changed
 
-			if (changed("read") || changed("write")) && !changed("device") {
-				return fmt.Errorf("-d is required when -r or -w is set")
+			limits, err := lf.limits(cmd)
+			if err != nil {
+				return err
 			}
 
-			var limits *taskerpb.ResourceLimits
-			if changed("cpu") || changed("memory") || changed("device") {
-				limits = &taskerpb.ResourceLimits{}
-				if changed("cpu") {
-					limits.Cpu = &cpu
-				}
+			base, err := parseEnvBase(envBase)
+			if err != nil {
+				return err
+			}
 
-				if changed("memory") {
-					limits.Memory = &memory
+			env := make(map[string]string)
+			if changed("env-file") {
+				if env, err = readEnvFile(envFile); err != nil {
+					return err
 				}
+			}
 
-				if changed("device") {
-					io := &taskerpb.IOLimits{Device: device}
-					if changed("read") {
-						io.Read = &read
-					}
-
-					if changed("write") {
-						io.Write = &write
-					}
-
-					limits.Io = io
+			// -e values win over the env file
+			for _, pair := range envVars {
+				key, value, ok := strings.Cut(pair, "=")
+				if !ok {
+					return fmt.Errorf("invalid -e %q (want KEY=VALUE)", pair)
 				}
+
+				env[key] = value
 			}
 
-			j, err := c.clt.StartJob(cmd.Context(), &taskerpb.StartJobRequest{
+			req := &taskerpb.StartJobRequest{
 				Command: args[0],
 				Args:    args[1:],
 				Limits:  limits,
-			})
+				Stdin:   stdin,
+				Tty:     tty,
+				Env:     env,
+				EnvBase: base,
+				Workdir: workdir,
+			}
+
+			if changed("timeout") {
+				seconds, err := durationSeconds("timeout", timeout, time.Second)
+				if err != nil {
+					return err
+				}
+
+				req.Timeout = &seconds
+			}
+
+			if changed("stop-signal") {
+				req.StopSignal = &stopSignal
+			}
+
+			if changed("stop-grace") {
+				seconds, err := durationSeconds("stop-grace", stopGrace, 0)
+				if err != nil {
+					return err
+				}
+
+				req.StopGrace = &seconds
+			}
+
+			j, err := c.clt.StartJob(cmd.Context(), req)
 			if err != nil {
 				return err
 			}
@@ -85,23 +304,122 @@ func (c *CLI) startJobCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().Float32VarP(&cpu, "cpu", "c", 0, "CPU limit in cores (e.g. 0.5)")
-	cmd.Flags().Uint32VarP(&memory, "memory", "m", 0, "Memory limit in MB")
-	cmd.Flags().StringVarP(&device, "device", "d", "", "Block device for IO limits")
-	cmd.Flags().Uint32VarP(&read, "read", "r", 0, "IO read limit in MB/s (requires -d)")
-	cmd.Flags().Uint32VarP(&write, "write", "w", 0, "IO write limit in MB/s (requires -d)")
+	lf.register(cmd)
+	cmd.Flags().BoolVarP(&stdin, "stdin", "i", false, "Keep stdin open for attach --stdin")
+	cmd.Flags().BoolVarP(&tty, "tty", "t", false, "Run under a pseudo-terminal (implies --stdin)")
+	cmd.Flags().StringArrayVarP(&envVars, "env", "e", nil, "Environment variable KEY=VALUE (repeatable)")
+	cmd.Flags().StringVar(&envFile, "env-file", "", "File of KEY=VALUE lines to add to the environment")
+	cmd.Flags().StringVar(&envBase, "env-base", "inherit", "Base environment (inherit, minimal, empty)")
+	cmd.Flags().StringVar(&workdir, "workdir", "", "Absolute working directory of the job")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Max runtime before the job is stopped (e.g. 30m)")
+	cmd.Flags().StringVar(&stopSignal, "stop-signal", "SIGTERM", "Signal sent when the job is stopped")
+	cmd.Flags().DurationVar(&stopGrace, "stop-grace", 2*time.Second, "Time to wait after the stop signal before the cgroup is killed")
 
 	c.withClient(cmd)
 	return cmd
 }
 
 func (c *CLI) stopJobCmd() *cobra.Command {
+	var sig string
+	var grace time.Duration
+
 	cmd := &cobra.Command{
 		Use:   "stop <id>",
 		Short: "Stop a Tasker job",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			j, err := c.clt.StopJob(cmd.Context(), args[0])
+			req := &taskerpb.StopJobRequest{Id: args[0]}
+			if cmd.Flags().Changed("signal") {
+				req.Signal = &sig
+			}
+
+			if cmd.Flags().Changed("grace") {
+				seconds, err := durationSeconds("grace", grace, 0)
+				if err != nil {
+					return err
+				}
+
+				req.Grace = &seconds
+			}
+
+			j, err := c.clt.StopJob(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			printJob(j)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&sig, "signal", "s", "", "Signal to send (e.g. SIGINT, defaults to the job's stop signal)")
+	cmd.Flags().DurationVarP(&grace, "grace", "g", 0, "Time to wait before the cgroup is killed (e.g. 30s, defaults to the job's stop grace)")
+
+	c.withClient(cmd)
+	return cmd
+}
+
+func (c *CLI) signalJobCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "signal <id> <signal>",
+		Short: "Send a signal to a Tasker job",
+		Long:  "Send a signal (e.g. HUP, SIGUSR1 or 10) to a running job without stopping it.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, signaled, err := c.clt.SignalJob(cmd.Context(), &taskerpb.SignalJobRequest{
+				Id:     args[0],
+				Signal: args[1],
+				All:    all,
+			})
+			if err != nil {
+				return err
+			}
+
+			if all {
+				fmt.Printf("sent %s to %d processes\n", args[1], signaled)
+			} else {
+				fmt.Printf("sent %s to the process group\n", args[1])
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Send to every process in the job's cgroup instead of the process group")
+
+	c.withClient(cmd)
+	return cmd
+}
+
+func (c *CLI) pauseJobCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause <id>",
+		Short: "Pause a Tasker job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			j, err := c.clt.PauseJob(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			printJob(j)
+			return nil
+		},
+	}
+
+	c.withClient(cmd)
+	return cmd
+}
+
+func (c *CLI) resumeJobCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume <id>",
+		Short: "Resume a paused Tasker job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			j, err := c.clt.ResumeJob(cmd.Context(), args[0])
 			if err != nil {
 				return err
 			}
@@ -115,6 +433,51 @@ func (c *CLI) stopJobCmd() *cobra.Command {
 	return cmd
 }
 
+func (c *CLI) updateJobCmd() *cobra.Command {
+	var (
+		lf      limitFlags
+		clearIO bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update [flags] <id>",
+		Short: "Update a Tasker job's resource limits",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if clearIO && cmd.Flags().Changed("device") {
+				return fmt.Errorf("--clear-io cannot be used with -d")
+			}
+
+			limits, err := lf.limits(cmd)
+			if err != nil {
+				return err
+			}
+
+			if limits == nil && !clearIO {
+				return fmt.Errorf("at least one limit is required")
+			}
+
+			j, err := c.clt.UpdateJobLimits(cmd.Context(), &taskerpb.UpdateJobLimitsRequest{
+				Id:      args[0],
+				Limits:  limits,
+				ClearIo: clearIO,
+			})
+			if err != nil {
+				return err
+			}
+
+			printJob(j)
+			return nil
+		},
+	}
+
+	lf.register(cmd)
+	cmd.Flags().BoolVar(&clearIO, "clear-io", false, "Lift all of the job's IO limits")
+
+	c.withClient(cmd)
+	return cmd
+}
+
 func (c *CLI) getJobCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get <id>",
@@ -135,22 +498,44 @@ func (c *CLI) getJobCmd() *cobra.Command {
 	return cmd
 }
 
-func (c *CLI) attachJobCmd() *cobra.Command {
+func (c *CLI) statsJobCmd() *cobra.Command {
+	var watch bool
+	var interval time.Duration
+
 	cmd := &cobra.Command{
-		Use:   "attach <id>",
-		Short: "Attach to a Tasker job",
+		Use:   "stats <id>",
+		Short: "Show a Tasker job's resource usage",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			stream, err := c.clt.AttachJob(cmd.Context(), args[0])
+			if !watch {
+				stats, err := c.clt.GetJobStats(cmd.Context(), args[0])
+				if err != nil {
+					return err
+				}
+
+				printStats(stats)
+				return nil
+			}
+
+			stream, err := c.clt.WatchJobStats(cmd.Context(), args[0], interval)
 			if err != nil {
 				return err
 			}
 
+			// Redraw in place when writing to a terminal
+			redraw := isTerminal(int(os.Stdout.Fd()))
 			for {
 				resp, err := stream.Recv()
 				switch {
 				case err == nil:
-					os.Stdout.Write(resp.Data)
+					if redraw {
+						fmt.Print("\033[H\033[2J")
+					}
+
+					printStats(resp.Stats)
+					if !redraw {
+						fmt.Println()
+					}
 				case err == io.EOF, status.Code(err) == codes.Canceled:
 					return nil
 				default:
@@ -160,44 +545,710 @@ func (c *CLI) attachJobCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep sampling until the job exits")
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "Time between samples with --watch (min 100ms)")
+
 	c.withClient(cmd)
 	return cmd
 }
 
+func (c *CLI) listJobsCmd() *cobra.Command {
+	var owner, phase, command, since, until, pageToken string
+	var limit uint32
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List Tasker jobs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changed := cmd.Flags().Changed
+
+			req := &taskerpb.ListJobsRequest{
+				Command:   command,
+				PageSize:  limit,
+				PageToken: pageToken,
+			}
+
+			if changed("owner") {
+				req.Owner = &owner
+			}
+
+			if changed("phase") {
+				p, err := parsePhase(phase)
+				if err != nil {
+					return err
+				}
+
+				req.Phase = p
+			}
+
+			if changed("since") {
+				t, err := parseTime(since)
+				if err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+
+				req.CreatedAfter = timestamppb.New(t)
+			}
+
+			if changed("until") {
+				t, err := parseTime(until)
+				if err != nil {
+					return fmt.Errorf("invalid --until: %w", err)
+				}
+
+				req.CreatedBefore = timestamppb.New(t)
+			}
+
+			jobs, next, err := c.clt.ListJobs(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "ID\tOWNER\tPHASE\tCREATED\tCOMMAND")
+			for _, j := range jobs {
+				fmt.Fprintf(
+					tw,
+					"%s\t%s\t%s\t%s\t%s\n",
+					j.Id,
+					j.Owner,
+					phaseName(j.Phase),
+					j.CreatedAt.AsTime().Local().Format(time.DateTime),
+					strings.Join(append([]string{j.Command}, j.Args...), " "),
+				)
+			}
+
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+
+			if next != "" {
+				fmt.Fprintf(os.Stderr, "more jobs available (--page-token %s)\n", next)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&owner, "owner", "o", "", "Only jobs owned by this user")
+	cmd.Flags().StringVarP(&phase, "phase", "p", "", "Only jobs in this phase (running, paused, stopped, completed, timed_out)")
+	cmd.Flags().StringVarP(&command, "command", "c", "", "Only jobs whose command line contains this text")
+	cmd.Flags().StringVar(&since, "since", "", "Only jobs created at or after this time (e.g. 1h or RFC 3339)")
+	cmd.Flags().StringVar(&until, "until", "", "Only jobs created before this time (e.g. 1h or RFC 3339)")
+	cmd.Flags().Uint32VarP(&limit, "limit", "l", 0, "Max jobs to list (server default 100)")
+	cmd.Flags().StringVar(&pageToken, "page-token", "", "Continue a previous listing")
+
+	c.withClient(cmd)
+	return cmd
+}
+
+func (c *CLI) attachJobCmd() *cobra.Command {
+	var stdin bool
+	var streamName string
+
+	cmd := &cobra.Command{
+		Use:   "attach <id>",
+		Short: "Attach to a Tasker job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputStream, ok := outputStreams[streamName]
+			if !ok {
+				return fmt.Errorf("unknown stream (stream=%s): want stdout, stderr or both", streamName)
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			output, err := c.clt.AttachJobOutput(ctx, &taskerpb.AttachJobRequest{Id: args[0], Stream: outputStream})
+			if err != nil {
+				return err
+			}
+
+			if stdin {
+				restore, err := c.sendStdin(ctx, args[0])
+				if err != nil {
+					return err
+				}
+
+				defer restore()
+			}
+
+			return printOutput(output, false)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&stdin, "stdin", "i", false, "Send local stdin to the job (job must be started with --stdin or --tty)")
+	cmd.Flags().StringVar(&streamName, "stream", "both", "Output stream to show (stdout, stderr or both)")
+
+	c.withClient(cmd)
+	return cmd
+}
+
+func (c *CLI) logsJobCmd() *cobra.Command {
+	var since, streamName string
+	var offset, tailBytes, tailLines uint64
+	var follow, timestamps bool
+
+	cmd := &cobra.Command{
+		Use:   "logs <id>",
+		Short: "Show a Tasker job's output",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputStream, ok := outputStreams[streamName]
+			if !ok {
+				return fmt.Errorf("unknown stream (stream=%s): want stdout, stderr or both", streamName)
+			}
+
+			req := &taskerpb.AttachJobRequest{
+				Id:        args[0],
+				Stream:    outputStream,
+				Offset:    offset,
+				TailBytes: tailBytes,
+				TailLines: tailLines,
+				NoFollow:  !follow,
+			}
+
+			if cmd.Flags().Changed("since") {
+				t, err := parseTime(since)
+				if err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+
+				req.Since = timestamppb.New(t)
+			}
+
+			output, err := c.clt.AttachJobOutput(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return printOutput(output, timestamps)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only output written at or after this time (e.g. 10m or RFC 3339)")
+	cmd.Flags().Uint64VarP(&tailLines, "tail", "n", 0, "Only the last N lines of output")
+	cmd.Flags().Uint64Var(&tailBytes, "tail-bytes", 0, "Only the last N bytes of output")
+	cmd.Flags().Uint64Var(&offset, "offset", 0, "Start at this byte of the output")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep showing new output until the job ends")
+	cmd.Flags().BoolVar(&timestamps, "timestamps", false, "Prefix each line with the time it was written")
+	cmd.Flags().StringVar(&streamName, "stream", "both", "Output stream to show (stdout, stderr or both)")
+
+	c.withClient(cmd)
+	return cmd
+}
+
+// printOutput writes a job's output to the local stdout and stderr until it ends.
+func printOutput(output *client.JobOutput, timestamps bool) error {
+	stdout := &outputWriter{w: os.Stdout, timestamps: timestamps}
+	stderr := &outputWriter{w: os.Stderr, timestamps: timestamps}
+
+	for {
+		resp, err := output.Recv()
+		switch {
+		case err == nil && resp.Truncated > 0:
+			fmt.Fprintf(os.Stderr, "[truncated %d bytes]\n", resp.Truncated)
+		case err == nil && resp.Stream == taskerpb.OutputStream_OUTPUT_STREAM_STDERR:
+			stderr.write(resp.Data, resp.Time.AsTime())
+		case err == nil:
+			stdout.write(resp.Data, resp.Time.AsTime())
+		case err == io.EOF, status.Code(err) == codes.Canceled:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// outputTimeFormat is the format of the time prefixed to output lines.
+const outputTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// outputWriter writes job output to a local stream, optionally prefixing each line with the time it was written.
+type outputWriter struct {
+	w          io.Writer
+	timestamps bool
+	// midLine is true when the last data written didn't end a line.
+	midLine bool
+}
+
+// write writes data that arrived at t.
+//
+// A line split across chunks is prefixed with the time of its first chunk.
+func (ow *outputWriter) write(data []byte, t time.Time) {
+	if !ow.timestamps {
+		_, _ = ow.w.Write(data)
+		return
+	}
+
+	prefix := t.Local().Format(outputTimeFormat) + " "
+	for len(data) > 0 {
+		if !ow.midLine {
+			_, _ = io.WriteString(ow.w, prefix)
+		}
+
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			_, _ = ow.w.Write(data)
+			ow.midLine = true
+			return
+		}
+
+		_, _ = ow.w.Write(data[:i+1])
+		data = data[i+1:]
+		ow.midLine = false
+	}
+}
+
+// sendStdin pipes the local stdin to the job in the background and returns a function that restores the local
+// terminal.
+//
+// For a job running under a terminal, the local terminal is put in raw mode and its window size is forwarded.
+func (c *CLI) sendStdin(ctx context.Context, id string) (func() error, error) {
+	j, err := c.clt.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := c.clt.SendJobInput(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	restore := func() error { return nil }
+	fd := int(os.Stdin.Fd())
+	if j.Tty && isTerminal(fd) {
+		restore, err = makeRaw(fd)
+		if err != nil {
+			return nil, fmt.Errorf("set raw mode: %w", err)
+		}
+
+		go forwardResize(ctx, input, fd)
+	}
+
+	// Pipe the local stdin to the job until local EOF
+	go func() {
+		_, err := io.Copy(input, os.Stdin)
+		if closeErr := input.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil && status.Code(err) != codes.Canceled {
+			fmt.Fprintf(os.Stderr, "stdin: %v\n", err)
+		}
+	}()
+
+	return restore, nil
+}
+
+// forwardResize sends the local terminal's window size to the job now and on every SIGWINCH until ctx ends.
+func forwardResize(ctx context.Context, input *client.JobInput, fd int) {
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, unix.SIGWINCH)
+	defer signal.Stop(winch)
+
+	for {
+		if rows, cols, err := windowSize(fd); err == nil {
+			_ = input.Resize(rows, cols)
+		}
+
+		select {
+		case <-winch:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// outputStreams maps CLI stream names to output streams.
+var outputStreams = map[string]taskerpb.OutputStream{
+	"both":   taskerpb.OutputStream_OUTPUT_STREAM_UNSPECIFIED,
+	"stdout": taskerpb.OutputStream_OUTPUT_STREAM_STDOUT,
+	"stderr": taskerpb.OutputStream_OUTPUT_STREAM_STDERR,
+}
+
+// phaseNames maps job phases to their CLI names.
+var phaseNames = map[taskerpb.JobPhase]string{
+	taskerpb.JobPhase_JOB_PHASE_RUNNING:   "running",
+	taskerpb.JobPhase_JOB_PHASE_STOPPED:   "stopped",
+	taskerpb.JobPhase_JOB_PHASE_COMPLETED: "completed",
+	taskerpb.JobPhase_JOB_PHASE_TIMED_OUT: "timed_out",
+	taskerpb.JobPhase_JOB_PHASE_PAUSED:    "paused",
+}
+
+// phaseName returns the CLI name of a job phase.
+func phaseName(phase taskerpb.JobPhase) string {
+	if name, ok := phaseNames[phase]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// exitReasonName returns the CLI name of an exit reason.
+func exitReasonName(reason taskerpb.ExitReason) string {
+	switch reason {
+	case taskerpb.ExitReason_EXIT_REASON_EXITED:
+		return "exited"
+	case taskerpb.ExitReason_EXIT_REASON_SIGNALED:
+		return "signaled"
+	case taskerpb.ExitReason_EXIT_REASON_OOM_KILLED:
+		return "oom_killed"
+	default:
+		return "unknown"
+	}
+}
+
+// parsePhase parses a CLI phase name.
+func parsePhase(name string) (taskerpb.JobPhase, error) {
+	for phase, n := range phaseNames {
+		if n == name {
+			return phase, nil
+		}
+	}
+
+	return taskerpb.JobPhase_JOB_PHASE_UNSPECIFIED, fmt.Errorf("unknown phase %q", name)
+}
+
+// durationSeconds converts a duration flag to whole seconds.
+func durationSeconds(flag string, d, minimum time.Duration) (uint32, error) {
+	if d < minimum || d > math.MaxUint32*time.Second {
+		return 0, fmt.Errorf("invalid --%s %s (want at least %s)", flag, d, minimum)
+	}
+
+	return uint32(d / time.Second), nil
+}
+
+// parseEnvBase parses a CLI env base name.
+func parseEnvBase(name string) (taskerpb.EnvBase, error) {
+	switch name {
+	case "inherit":
+		return taskerpb.EnvBase_ENV_BASE_UNSPECIFIED, nil
+	case "minimal":
+		return taskerpb.EnvBase_ENV_BASE_MINIMAL, nil
+	case "empty":
+		return taskerpb.EnvBase_ENV_BASE_EMPTY, nil
+	default:
+		return taskerpb.EnvBase_ENV_BASE_UNSPECIFIED, fmt.Errorf("unknown env base %q", name)
+	}
+}
+
+// readEnvFile reads KEY=VALUE lines from a file, skipping blank lines and # comments.
+func readEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read env file: %w", err)
+	}
+
+	env := make(map[string]string)
+	for num, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid env file line %d (want KEY=VALUE)", num+1)
+		}
+
+		env[key] = value
+	}
+
+	return env, nil
+}
+
+// parseTime parses either a duration ago (e.g. 10m) or an RFC 3339 timestamp.
+func parseTime(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// formatTime formats a proto timestamp in local time.
+func formatTime(ts *timestamppb.Timestamp) string {
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
+
 // printJob prints a job's info to stdout.
 func printJob(j *taskerpb.Job) {
-	var phase string
-	switch j.Phase {
-	case taskerpb.JobPhase_JOB_PHASE_RUNNING:
-		phase = "running"
-	case taskerpb.JobPhase_JOB_PHASE_STOPPED:
-		phase = "stopped"
-	case taskerpb.JobPhase_JOB_PHASE_COMPLETED:
-		phase = "completed"
-	default:
-		phase = "unknown"
+	fmt.Printf(
+		"id: %s\nowner: %s\ncommand: %s\nargs: %v\nphase: %s\n",
+		j.Id,
+		j.Owner,
+		j.Command,
+		j.Args,
+		phaseName(j.Phase),
+	)
+
+	fmt.Printf("run as: uid=%d gid=%d", j.Uid, j.Gid)
+	if len(j.Groups) > 0 {
+		fmt.Printf(" groups=%v", j.Groups)
+	}
+	fmt.Println()
+
+	if j.Tty {
+		fmt.Println("tty: true")
+	}
+
+	if j.Workdir != "" {
+		fmt.Printf("workdir: %s\n", j.Workdir)
+	}
+
+	if j.Timeout > 0 {
+		fmt.Printf("timeout: %s\n", time.Duration(j.Timeout)*time.Second)
+	}
+
+	if j.StopSignal != "" {
+		fmt.Printf("stop signal: %s\nstop grace: %s\n", j.StopSignal, time.Duration(j.StopGrace)*time.Second)
 	}
 
-	fmt.Printf("id: %s\nowner: %s\ncommand: %s\nargs: %v\nphase: %s\n", j.Id, j.Owner, j.Command, j.Args, phase)
+	if j.CreatedAt != nil {
+		fmt.Printf("created: %s\n", formatTime(j.CreatedAt))
+	}
+
+	if j.StartedAt != nil {
+		fmt.Printf("started: %s\n", formatTime(j.StartedAt))
+	}
+
+	if j.FinishedAt != nil {
+		fmt.Printf("finished: %s\n", formatTime(j.FinishedAt))
+	}
+
+	if j.Exit != nil {
+		fmt.Printf("exit reason: %s\nexit code: %d\n", exitReasonName(j.Exit.Reason), j.Exit.Code)
+		if j.Exit.Signal != "" {
+			fmt.Printf("signal: %s\n", j.Exit.Signal)
+		}
+
+		if j.Exit.CoreDumped {
+			fmt.Println("core dumped: true")
+		}
+	}
+
+	if j.Error != "" {
+		fmt.Printf("error: %s\n", j.Error)
+	}
 
 	if j.Limits != nil {
 		if j.Limits.Cpu != nil {
 			fmt.Printf("cpu limit: %.2f cores\n", *j.Limits.Cpu)
 		}
 
+		if j.Limits.CpuBurst != nil {
+			fmt.Printf("cpu burst: %.2f cores\n", *j.Limits.CpuBurst)
+		}
+
+		if j.Limits.CpuWeight != nil {
+			fmt.Printf("cpu weight: %d\n", *j.Limits.CpuWeight)
+		}
+
+		if j.Limits.CpusetCpus != nil {
+			fmt.Printf("cpus: %s\n", *j.Limits.CpusetCpus)
+		}
+
+		if j.Limits.CpusetMems != nil {
+			fmt.Printf("mems: %s\n", *j.Limits.CpusetMems)
+		}
+
 		if j.Limits.Memory != nil {
 			fmt.Printf("memory limit: %d MB\n", *j.Limits.Memory)
 		}
 
-		if j.Limits.Io != nil {
-			fmt.Printf("io device: %s\n", j.Limits.Io.Device)
-			if j.Limits.Io.Read != nil {
-				fmt.Printf("io read limit: %d MB/s\n", *j.Limits.Io.Read)
+		if j.Limits.MemoryHigh != nil {
+			fmt.Printf("memory high: %d MB\n", *j.Limits.MemoryHigh)
+		}
+
+		if j.Limits.MemoryLow != nil {
+			fmt.Printf("memory low: %d MB\n", *j.Limits.MemoryLow)
+		}
+
+		if j.Limits.MemoryMin != nil {
+			fmt.Printf("memory min: %d MB\n", *j.Limits.MemoryMin)
+		}
+
+		if j.Limits.Swap != nil {
+			fmt.Printf("swap limit: %d MB\n", *j.Limits.Swap)
+		}
+
+		for _, limit := range j.Limits.Io {
+			fmt.Printf("io device: %s\n", limit.Device)
+			if limit.Read != nil {
+				fmt.Printf("  read limit: %d MB/s\n", *limit.Read)
+			}
+
+			if limit.Write != nil {
+				fmt.Printf("  write limit: %d MB/s\n", *limit.Write)
+			}
+
+			if limit.ReadIops != nil {
+				fmt.Printf("  read iops limit: %d\n", *limit.ReadIops)
 			}
 
-			if j.Limits.Io.Write != nil {
-				fmt.Printf("io write limit: %d MB/s\n", *j.Limits.Io.Write)
+			if limit.WriteIops != nil {
+				fmt.Printf("  write iops limit: %d\n", *limit.WriteIops)
 			}
+
+			if limit.Weight != nil {
+				fmt.Printf("  weight: %d\n", *limit.Weight)
+			}
+
+			if limit.Latency != nil {
+				fmt.Printf("  latency target: %d usec\n", *limit.Latency)
+			}
+		}
+
+		if j.Limits.Pids != nil {
+			fmt.Printf("pids limit: %d\n", *j.Limits.Pids)
+		}
+
+		if j.Limits.OomGroup != nil {
+			fmt.Printf("oom group: %t\n", *j.Limits.OomGroup)
 		}
 	}
+
+	if j.OutputDropped > 0 {
+		fmt.Printf("output dropped: %s\n", formatBytes(j.OutputDropped))
+	}
+
+	if j.PidsMaxEvents > 0 {
+		fmt.Printf("pids limit hits: %d\n", j.PidsMaxEvents)
+	}
+
+	if j.Usage != nil {
+		printUsage(j.Usage)
+	}
+
+	if len(j.Transitions) > 0 {
+		fmt.Println("transitions:")
+		for _, t := range j.Transitions {
+			fmt.Printf("  %s -> %s at %s", phaseName(t.From), phaseName(t.To), formatTime(t.Time))
+			if t.By != "" {
+				fmt.Printf(" by %s", t.By)
+			}
+
+			fmt.Println()
+		}
+	}
+}
+
+// printStats prints a job's resource usage to stdout.
+func printStats(stats *taskerpb.JobStats) {
+	if stats.Time != nil {
+		fmt.Printf("time: %s", formatTime(stats.Time))
+		if stats.Final {
+			fmt.Print(" (final)")
+		}
+
+		fmt.Println()
+	}
+
+	if cpu := stats.Cpu; cpu != nil {
+		fmt.Printf(
+			"cpu usage: %s (user %s, system %s)\n",
+			formatUsec(cpu.UsageUsec),
+			formatUsec(cpu.UserUsec),
+			formatUsec(cpu.SystemUsec),
+		)
+
+		if cpu.NrPeriods > 0 {
+			fmt.Printf(
+				"cpu throttled: %d of %d periods (%s)\n",
+				cpu.NrThrottled,
+				cpu.NrPeriods,
+				formatUsec(cpu.ThrottledUsec),
+			)
+		}
+	}
+
+	if memory := stats.Memory; memory != nil {
+		fmt.Printf("memory current: %s\n", formatBytes(memory.Current))
+		if memory.Peak > 0 {
+			fmt.Printf("memory peak: %s\n", formatBytes(memory.Peak))
+		}
+
+		fmt.Printf(
+			"memory breakdown: anon %s, file %s, kernel %s, shmem %s, sock %s\n",
+			formatBytes(memory.Anon),
+			formatBytes(memory.File),
+			formatBytes(memory.Kernel),
+			formatBytes(memory.Shmem),
+			formatBytes(memory.Sock),
+		)
+		fmt.Printf("page faults: %d (major %d)\n", memory.Pgfault, memory.Pgmajfault)
+		fmt.Printf("oom events: %d (kills %d)\n", memory.Oom, memory.OomKill)
+	}
+
+	for _, device := range stats.Io {
+		fmt.Printf(
+			"io %s: read %s (%d ops), write %s (%d ops)\n",
+			device.Device,
+			formatBytes(device.Rbytes),
+			device.Rios,
+			formatBytes(device.Wbytes),
+			device.Wios,
+		)
+	}
+
+	if stats.Pids != nil {
+		fmt.Printf("pids current: %d\n", stats.Pids.Current)
+	}
+}
+
+// printUsage prints a finished job's final resource usage summary to stdout.
+func printUsage(usage *taskerpb.JobStats) {
+	fmt.Println("usage:")
+	if cpu := usage.Cpu; cpu != nil {
+		fmt.Printf(
+			"  cpu time: %s (user %s, system %s)\n",
+			formatUsec(cpu.UsageUsec),
+			formatUsec(cpu.UserUsec),
+			formatUsec(cpu.SystemUsec),
+		)
+
+		if cpu.NrThrottled > 0 {
+			fmt.Printf("  cpu throttled: %d of %d periods (%s)\n", cpu.NrThrottled, cpu.NrPeriods, formatUsec(cpu.ThrottledUsec))
+		}
+	}
+
+	if memory := usage.Memory; memory != nil {
+		if memory.Peak > 0 {
+			fmt.Printf("  memory peak: %s\n", formatBytes(memory.Peak))
+		}
+
+		if memory.Oom > 0 || memory.OomKill > 0 {
+			fmt.Printf("  oom events: %d (kills %d)\n", memory.Oom, memory.OomKill)
+		}
+	}
+
+	var read, written uint64
+	for _, device := range usage.Io {
+		read += device.Rbytes
+		written += device.Wbytes
+	}
+
+	fmt.Printf("  io read: %s\n  io written: %s\n", formatBytes(read), formatBytes(written))
+}
+
+// formatUsec formats microseconds as a duration.
+func formatUsec(usec uint64) string {
+	return (time.Duration(usec) * time.Microsecond).String()
+}
+
+// formatBytes formats a byte count with a binary unit (e.g. 1.5 MiB).
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
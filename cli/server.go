@@ -1,25 +1,50 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/wolves-fc/tasker/lib/server"
 )
 
 func (c *CLI) serverCmd() *cobra.Command {
-	var name, addr string
+	cfg := server.Config{}
+	var runAs []string
+	var defaultRunAs string
+	var outputLimit uint32
 
 	cmd := &cobra.Command{
 		Use:   "server",
 		Short: "Start a Tasker server",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return server.New(cmd.Context(), c.certDir, name, addr)
+			cfg.CertDir = c.certDir
+
+			// Every job gets pids.max set to this, so 0 would leave jobs unable to fork
+			if cfg.PIDs == 0 {
+				return fmt.Errorf("--pids must be at least 1")
+			}
+
+			users, err := server.ParseUserMap(runAs, defaultRunAs)
+			if err != nil {
+				return err
+			}
+
+			cfg.Users = users
+			// MB -> bytes
+			cfg.OutputLimit = int64(outputLimit) * 1024 * 1024
+			return server.New(cmd.Context(), cfg)
 		},
 	}
 
-	cmd.Flags().StringVarP(&name, "name", "n", "wolfpack1", "Server name (cert name)")
-	cmd.Flags().StringVarP(&addr, "addr", "a", ":50051", "Listen address")
+	cmd.Flags().StringVarP(&cfg.Name, "name", "n", "wolfpack1", "Server name (cert name)")
+	cmd.Flags().StringVarP(&cfg.Addr, "addr", "a", ":50051", "Listen address")
+	cmd.Flags().Uint32Var(&cfg.PIDs, "pids", 1000, "Default and max processes per job")
+	cmd.Flags().StringArrayVar(&runAs, "run-as", nil, "Run an identity's jobs as a Linux account (<name>=<account> or role:<role>=<account>)")
+	cmd.Flags().StringVar(&defaultRunAs, "default-run-as", "", "Run jobs of unmapped identities as this Linux account (e.g. nobody)")
+	cmd.Flags().Uint32Var(&outputLimit, "output-limit", 64, "Max output kept per job in MB, dropping the oldest bytes (0 = unlimited)")
+	cmd.Flags().StringVar(&cfg.DataDir, "data-dir", "/var/lib/tasker", "Directory job output is spilled to (empty keeps output in memory)")
 
 	return cmd
 }
@@ -9,6 +9,7 @@ package tasker
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -33,6 +34,10 @@ const (
 	JobPhase_JOB_PHASE_STOPPED JobPhase = 2
 	// Job exited on its own.
 	JobPhase_JOB_PHASE_COMPLETED JobPhase = 3
+	// Job was stopped because it ran past its timeout.
+	JobPhase_JOB_PHASE_TIMED_OUT JobPhase = 4
+	// Job is frozen and can be resumed.
+	JobPhase_JOB_PHASE_PAUSED JobPhase = 5
 )
 
 // Enum value maps for JobPhase.
@@ -42,12 +47,16 @@ var (
 		1: "JOB_PHASE_RUNNING",
 		2: "JOB_PHASE_STOPPED",
 		3: "JOB_PHASE_COMPLETED",
+		4: "JOB_PHASE_TIMED_OUT",
+		5: "JOB_PHASE_PAUSED",
 	}
 	JobPhase_value = map[string]int32{
 		"JOB_PHASE_UNSPECIFIED": 0,
 		"JOB_PHASE_RUNNING":     1,
 		"JOB_PHASE_STOPPED":     2,
 		"JOB_PHASE_COMPLETED":   3,
+		"JOB_PHASE_TIMED_OUT":   4,
+		"JOB_PHASE_PAUSED":      5,
 	}
 )
 
@@ -78,6 +87,169 @@ func (JobPhase) EnumDescriptor() ([]byte, []int) {
 	return file_tasker_tasker_proto_rawDescGZIP(), []int{0}
 }
 
+// ExitReason is why a job's process exited.
+type ExitReason int32
+
+const (
+	// Unknown or the process has not exited.
+	ExitReason_EXIT_REASON_UNSPECIFIED ExitReason = 0
+	// Process exited on its own with an exit code.
+	ExitReason_EXIT_REASON_EXITED ExitReason = 1
+	// Process was terminated by a signal.
+	ExitReason_EXIT_REASON_SIGNALED ExitReason = 2
+	// The OOM killer killed one of the job's processes after it hit its memory limit (the exit code or signal is kept).
+	ExitReason_EXIT_REASON_OOM_KILLED ExitReason = 3
+)
+
+// Enum value maps for ExitReason.
+var (
+	ExitReason_name = map[int32]string{
+		0: "EXIT_REASON_UNSPECIFIED",
+		1: "EXIT_REASON_EXITED",
+		2: "EXIT_REASON_SIGNALED",
+		3: "EXIT_REASON_OOM_KILLED",
+	}
+	ExitReason_value = map[string]int32{
+		"EXIT_REASON_UNSPECIFIED": 0,
+		"EXIT_REASON_EXITED":      1,
+		"EXIT_REASON_SIGNALED":    2,
+		"EXIT_REASON_OOM_KILLED":  3,
+	}
+)
+
+func (x ExitReason) Enum() *ExitReason {
+	p := new(ExitReason)
+	*p = x
+	return p
+}
+
+func (x ExitReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExitReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasker_tasker_proto_enumTypes[1].Descriptor()
+}
+
+func (ExitReason) Type() protoreflect.EnumType {
+	return &file_tasker_tasker_proto_enumTypes[1]
+}
+
+func (x ExitReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExitReason.Descriptor instead.
+func (ExitReason) EnumDescriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{1}
+}
+
+// OutputStream is the stream a job's output was written to.
+type OutputStream int32
+
+const (
+	// Both streams when selecting output.
+	OutputStream_OUTPUT_STREAM_UNSPECIFIED OutputStream = 0
+	// Standard output (also all output of a job running under a terminal).
+	OutputStream_OUTPUT_STREAM_STDOUT OutputStream = 1
+	// Standard error.
+	OutputStream_OUTPUT_STREAM_STDERR OutputStream = 2
+)
+
+// Enum value maps for OutputStream.
+var (
+	OutputStream_name = map[int32]string{
+		0: "OUTPUT_STREAM_UNSPECIFIED",
+		1: "OUTPUT_STREAM_STDOUT",
+		2: "OUTPUT_STREAM_STDERR",
+	}
+	OutputStream_value = map[string]int32{
+		"OUTPUT_STREAM_UNSPECIFIED": 0,
+		"OUTPUT_STREAM_STDOUT":      1,
+		"OUTPUT_STREAM_STDERR":      2,
+	}
+)
+
+func (x OutputStream) Enum() *OutputStream {
+	p := new(OutputStream)
+	*p = x
+	return p
+}
+
+func (x OutputStream) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputStream) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasker_tasker_proto_enumTypes[2].Descriptor()
+}
+
+func (OutputStream) Type() protoreflect.EnumType {
+	return &file_tasker_tasker_proto_enumTypes[2]
+}
+
+func (x OutputStream) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputStream.Descriptor instead.
+func (OutputStream) EnumDescriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{2}
+}
+
+// EnvBase is the environment a job starts from before its own variables are applied.
+type EnvBase int32
+
+const (
+	// Inherit the server's environment.
+	EnvBase_ENV_BASE_UNSPECIFIED EnvBase = 0
+	// Only a default PATH (and TERM under a terminal).
+	EnvBase_ENV_BASE_MINIMAL EnvBase = 1
+	// No variables at all.
+	EnvBase_ENV_BASE_EMPTY EnvBase = 2
+)
+
+// Enum value maps for EnvBase.
+var (
+	EnvBase_name = map[int32]string{
+		0: "ENV_BASE_UNSPECIFIED",
+		1: "ENV_BASE_MINIMAL",
+		2: "ENV_BASE_EMPTY",
+	}
+	EnvBase_value = map[string]int32{
+		"ENV_BASE_UNSPECIFIED": 0,
+		"ENV_BASE_MINIMAL":     1,
+		"ENV_BASE_EMPTY":       2,
+	}
+)
+
+func (x EnvBase) Enum() *EnvBase {
+	p := new(EnvBase)
+	*p = x
+	return p
+}
+
+func (x EnvBase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EnvBase) Descriptor() protoreflect.EnumDescriptor {
+	return file_tasker_tasker_proto_enumTypes[3].Descriptor()
+}
+
+func (EnvBase) Type() protoreflect.EnumType {
+	return &file_tasker_tasker_proto_enumTypes[3]
+}
+
+func (x EnvBase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EnvBase.Descriptor instead.
+func (EnvBase) EnumDescriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{3}
+}
+
 // ResourceLimits holds optional resource limits for a job.
 type ResourceLimits struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -85,8 +257,28 @@ type ResourceLimits struct {
 	Cpu *float32 `protobuf:"fixed32,1,opt,name=cpu,proto3,oneof" json:"cpu,omitempty"`
 	// Memory limit in MB.
 	Memory *uint32 `protobuf:"varint,2,opt,name=memory,proto3,oneof" json:"memory,omitempty"`
-	// IO limits for a block device.
-	Io            *IOLimits `protobuf:"bytes,3,opt,name=io,proto3,oneof" json:"io,omitempty"`
+	// IO limits per block device (each device must be a different disk).
+	Io []*IOLimits `protobuf:"bytes,3,rep,name=io,proto3" json:"io,omitempty"`
+	// Max number of processes (defaults to the server limit and cannot exceed it).
+	Pids *uint32 `protobuf:"varint,4,opt,name=pids,proto3,oneof" json:"pids,omitempty"`
+	// Kill every process in the job together when the OOM killer picks one of them (memory.oom.group).
+	OomGroup *bool `protobuf:"varint,5,opt,name=oom_group,json=oomGroup,proto3,oneof" json:"oom_group,omitempty"`
+	// Memory throttle threshold in MB (memory.high, cannot exceed memory).
+	MemoryHigh *uint32 `protobuf:"varint,6,opt,name=memory_high,json=memoryHigh,proto3,oneof" json:"memory_high,omitempty"`
+	// Best effort memory protection in MB (memory.low, cannot exceed memory).
+	MemoryLow *uint32 `protobuf:"varint,7,opt,name=memory_low,json=memoryLow,proto3,oneof" json:"memory_low,omitempty"`
+	// Hard memory protection in MB (memory.min, cannot exceed memory).
+	MemoryMin *uint32 `protobuf:"varint,8,opt,name=memory_min,json=memoryMin,proto3,oneof" json:"memory_min,omitempty"`
+	// Swap limit in MB (memory.swap.max, 0 disables swap).
+	Swap *uint32 `protobuf:"varint,9,opt,name=swap,proto3,oneof" json:"swap,omitempty"`
+	// Relative CPU share against other jobs (cpu.weight, 1-10000, kernel default 100).
+	CpuWeight *uint32 `protobuf:"varint,10,opt,name=cpu_weight,json=cpuWeight,proto3,oneof" json:"cpu_weight,omitempty"`
+	// Extra CPU in cores a job can borrow from unused quota in earlier periods (cpu.max.burst, cannot exceed cpu).
+	CpuBurst *float32 `protobuf:"fixed32,11,opt,name=cpu_burst,json=cpuBurst,proto3,oneof" json:"cpu_burst,omitempty"`
+	// CPUs the job can run on (cpuset.cpus, e.g. 0-3,8).
+	CpusetCpus *string `protobuf:"bytes,12,opt,name=cpuset_cpus,json=cpusetCpus,proto3,oneof" json:"cpuset_cpus,omitempty"`
+	// NUMA memory nodes the job can allocate from (cpuset.mems, e.g. 0).
+	CpusetMems    *string `protobuf:"bytes,13,opt,name=cpuset_mems,json=cpusetMems,proto3,oneof" json:"cpuset_mems,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -135,13 +327,83 @@ func (x *ResourceLimits) GetMemory() uint32 {
 	return 0
 }
 
-func (x *ResourceLimits) GetIo() *IOLimits {
+func (x *ResourceLimits) GetIo() []*IOLimits {
 	if x != nil {
 		return x.Io
 	}
 	return nil
 }
 
+func (x *ResourceLimits) GetPids() uint32 {
+	if x != nil && x.Pids != nil {
+		return *x.Pids
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetOomGroup() bool {
+	if x != nil && x.OomGroup != nil {
+		return *x.OomGroup
+	}
+	return false
+}
+
+func (x *ResourceLimits) GetMemoryHigh() uint32 {
+	if x != nil && x.MemoryHigh != nil {
+		return *x.MemoryHigh
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetMemoryLow() uint32 {
+	if x != nil && x.MemoryLow != nil {
+		return *x.MemoryLow
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetMemoryMin() uint32 {
+	if x != nil && x.MemoryMin != nil {
+		return *x.MemoryMin
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetSwap() uint32 {
+	if x != nil && x.Swap != nil {
+		return *x.Swap
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetCpuWeight() uint32 {
+	if x != nil && x.CpuWeight != nil {
+		return *x.CpuWeight
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetCpuBurst() float32 {
+	if x != nil && x.CpuBurst != nil {
+		return *x.CpuBurst
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetCpusetCpus() string {
+	if x != nil && x.CpusetCpus != nil {
+		return *x.CpusetCpus
+	}
+	return ""
+}
+
+func (x *ResourceLimits) GetCpusetMems() string {
+	if x != nil && x.CpusetMems != nil {
+		return *x.CpusetMems
+	}
+	return ""
+}
+
 // IOLimits holds IO limits for a block device.
 type IOLimits struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -150,7 +412,15 @@ type IOLimits struct {
 	// Read limit in MB/s.
 	Read *uint32 `protobuf:"varint,2,opt,name=read,proto3,oneof" json:"read,omitempty"`
 	// Write limit in MB/s.
-	Write         *uint32 `protobuf:"varint,3,opt,name=write,proto3,oneof" json:"write,omitempty"`
+	Write *uint32 `protobuf:"varint,3,opt,name=write,proto3,oneof" json:"write,omitempty"`
+	// Read limit in IO operations per second.
+	ReadIops *uint32 `protobuf:"varint,4,opt,name=read_iops,json=readIops,proto3,oneof" json:"read_iops,omitempty"`
+	// Write limit in IO operations per second.
+	WriteIops *uint32 `protobuf:"varint,5,opt,name=write_iops,json=writeIops,proto3,oneof" json:"write_iops,omitempty"`
+	// Proportional share of the device (io.weight, 1-10000).
+	Weight *uint32 `protobuf:"varint,6,opt,name=weight,proto3,oneof" json:"weight,omitempty"`
+	// Completion latency target in microseconds (io.latency).
+	Latency       *uint32 `protobuf:"varint,7,opt,name=latency,proto3,oneof" json:"latency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -192,54 +462,1950 @@ func (x *IOLimits) GetDevice() string {
 	return ""
 }
 
-func (x *IOLimits) GetRead() uint32 {
-	if x != nil && x.Read != nil {
-		return *x.Read
+func (x *IOLimits) GetRead() uint32 {
+	if x != nil && x.Read != nil {
+		return *x.Read
+	}
+	return 0
+}
+
+func (x *IOLimits) GetWrite() uint32 {
+	if x != nil && x.Write != nil {
+		return *x.Write
+	}
+	return 0
+}
+
+func (x *IOLimits) GetReadIops() uint32 {
+	if x != nil && x.ReadIops != nil {
+		return *x.ReadIops
+	}
+	return 0
+}
+
+func (x *IOLimits) GetWriteIops() uint32 {
+	if x != nil && x.WriteIops != nil {
+		return *x.WriteIops
+	}
+	return 0
+}
+
+func (x *IOLimits) GetWeight() uint32 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+func (x *IOLimits) GetLatency() uint32 {
+	if x != nil && x.Latency != nil {
+		return *x.Latency
+	}
+	return 0
+}
+
+// ExitStatus describes how a job's process exited.
+type ExitStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Exit code or -1 if the process was terminated by a signal.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Name of the signal that terminated the process (e.g. SIGKILL).
+	Signal string `protobuf:"bytes,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	// Whether the process dumped core.
+	CoreDumped bool `protobuf:"varint,3,opt,name=core_dumped,json=coreDumped,proto3" json:"core_dumped,omitempty"`
+	// Why the process exited.
+	Reason        ExitReason `protobuf:"varint,4,opt,name=reason,proto3,enum=tasker.ExitReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExitStatus) Reset() {
+	*x = ExitStatus{}
+	mi := &file_tasker_tasker_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExitStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitStatus) ProtoMessage() {}
+
+func (x *ExitStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitStatus.ProtoReflect.Descriptor instead.
+func (*ExitStatus) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExitStatus) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ExitStatus) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *ExitStatus) GetCoreDumped() bool {
+	if x != nil {
+		return x.CoreDumped
+	}
+	return false
+}
+
+func (x *ExitStatus) GetReason() ExitReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ExitReason_EXIT_REASON_UNSPECIFIED
+}
+
+// PhaseTransition records a change in a job's phase.
+type PhaseTransition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Phase before the transition.
+	From JobPhase `protobuf:"varint,1,opt,name=from,proto3,enum=tasker.JobPhase" json:"from,omitempty"`
+	// Phase after the transition.
+	To JobPhase `protobuf:"varint,2,opt,name=to,proto3,enum=tasker.JobPhase" json:"to,omitempty"`
+	// When the transition happened.
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Who triggered the transition (empty when the job exited on its own).
+	By            string `protobuf:"bytes,4,opt,name=by,proto3" json:"by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseTransition) Reset() {
+	*x = PhaseTransition{}
+	mi := &file_tasker_tasker_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseTransition) ProtoMessage() {}
+
+func (x *PhaseTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseTransition.ProtoReflect.Descriptor instead.
+func (*PhaseTransition) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PhaseTransition) GetFrom() JobPhase {
+	if x != nil {
+		return x.From
+	}
+	return JobPhase_JOB_PHASE_UNSPECIFIED
+}
+
+func (x *PhaseTransition) GetTo() JobPhase {
+	if x != nil {
+		return x.To
+	}
+	return JobPhase_JOB_PHASE_UNSPECIFIED
+}
+
+func (x *PhaseTransition) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *PhaseTransition) GetBy() string {
+	if x != nil {
+		return x.By
+	}
+	return ""
+}
+
+// Job represents a managed process.
+type Job struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// User who started this job.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Path to the executable.
+	Command string `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	// Arguments to the executable.
+	Args []string `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	// Current lifecycle phase.
+	Phase JobPhase `protobuf:"varint,5,opt,name=phase,proto3,enum=tasker.JobPhase" json:"phase,omitempty"`
+	// Resource limits (optional).
+	Limits *ResourceLimits `protobuf:"bytes,6,opt,name=limits,proto3" json:"limits,omitempty"`
+	// Exit status (set once the process has exited).
+	Exit *ExitStatus `protobuf:"bytes,7,opt,name=exit,proto3" json:"exit,omitempty"`
+	// Human readable error if the job failed.
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// When the job was created.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// When the process was started.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// When the process exited (unset while running).
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// Phase transitions in order.
+	Transitions []*PhaseTransition `protobuf:"bytes,12,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	// Number of times a fork failed because the pids limit was hit.
+	PidsMaxEvents uint64 `protobuf:"varint,13,opt,name=pids_max_events,json=pidsMaxEvents,proto3" json:"pids_max_events,omitempty"`
+	// Whether the job runs under a pseudo-terminal.
+	Tty bool `protobuf:"varint,14,opt,name=tty,proto3" json:"tty,omitempty"`
+	// Working directory (empty if the job uses the server's working directory).
+	Workdir string `protobuf:"bytes,15,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	// Linux user ID the process runs as.
+	Uid uint32 `protobuf:"varint,16,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Linux group ID the process runs as.
+	Gid uint32 `protobuf:"varint,17,opt,name=gid,proto3" json:"gid,omitempty"`
+	// Supplementary Linux group IDs.
+	Groups []uint32 `protobuf:"varint,18,rep,packed,name=groups,proto3" json:"groups,omitempty"`
+	// Max runtime in seconds (0 if the job has no timeout).
+	Timeout uint32 `protobuf:"varint,19,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Signal sent to the process group when the job is stopped.
+	StopSignal string `protobuf:"bytes,20,opt,name=stop_signal,json=stopSignal,proto3" json:"stop_signal,omitempty"`
+	// Seconds to wait after the stop signal before the cgroup is killed.
+	StopGrace uint32 `protobuf:"varint,21,opt,name=stop_grace,json=stopGrace,proto3" json:"stop_grace,omitempty"`
+	// Final resource usage (set once the process has exited).
+	Usage *JobStats `protobuf:"bytes,22,opt,name=usage,proto3" json:"usage,omitempty"`
+	// Output bytes dropped over the server's output limit.
+	OutputDropped uint64 `protobuf:"varint,23,opt,name=output_dropped,json=outputDropped,proto3" json:"output_dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_tasker_tasker_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Job) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Job) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Job) GetPhase() JobPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return JobPhase_JOB_PHASE_UNSPECIFIED
+}
+
+func (x *Job) GetLimits() *ResourceLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *Job) GetExit() *ExitStatus {
+	if x != nil {
+		return x.Exit
+	}
+	return nil
+}
+
+func (x *Job) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Job) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *Job) GetTransitions() []*PhaseTransition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+func (x *Job) GetPidsMaxEvents() uint64 {
+	if x != nil {
+		return x.PidsMaxEvents
+	}
+	return 0
+}
+
+func (x *Job) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+func (x *Job) GetWorkdir() string {
+	if x != nil {
+		return x.Workdir
+	}
+	return ""
+}
+
+func (x *Job) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Job) GetGid() uint32 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
+func (x *Job) GetGroups() []uint32 {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *Job) GetTimeout() uint32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *Job) GetStopSignal() string {
+	if x != nil {
+		return x.StopSignal
+	}
+	return ""
+}
+
+func (x *Job) GetStopGrace() uint32 {
+	if x != nil {
+		return x.StopGrace
+	}
+	return 0
+}
+
+func (x *Job) GetUsage() *JobStats {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *Job) GetOutputDropped() uint64 {
+	if x != nil {
+		return x.OutputDropped
+	}
+	return 0
+}
+
+// JobStats is a sample of a job's resource usage read from its cgroup.
+type JobStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the sample was taken.
+	Time   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Cpu    *CPUStats              `protobuf:"bytes,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory *MemoryStats           `protobuf:"bytes,3,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Usage for each block device the job has used.
+	Io   []*IOStats `protobuf:"bytes,4,rep,name=io,proto3" json:"io,omitempty"`
+	Pids *PIDsStats `protobuf:"bytes,5,opt,name=pids,proto3" json:"pids,omitempty"`
+	// Whether this is the final sample taken when the process exited.
+	Final         bool `protobuf:"varint,6,opt,name=final,proto3" json:"final,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobStats) Reset() {
+	*x = JobStats{}
+	mi := &file_tasker_tasker_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobStats) ProtoMessage() {}
+
+func (x *JobStats) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobStats.ProtoReflect.Descriptor instead.
+func (*JobStats) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *JobStats) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *JobStats) GetCpu() *CPUStats {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *JobStats) GetMemory() *MemoryStats {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *JobStats) GetIo() []*IOStats {
+	if x != nil {
+		return x.Io
+	}
+	return nil
+}
+
+func (x *JobStats) GetPids() *PIDsStats {
+	if x != nil {
+		return x.Pids
+	}
+	return nil
+}
+
+func (x *JobStats) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+// CPUStats holds usage from cpu.stat in microseconds.
+type CPUStats struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UsageUsec  uint64                 `protobuf:"varint,1,opt,name=usage_usec,json=usageUsec,proto3" json:"usage_usec,omitempty"`
+	UserUsec   uint64                 `protobuf:"varint,2,opt,name=user_usec,json=userUsec,proto3" json:"user_usec,omitempty"`
+	SystemUsec uint64                 `protobuf:"varint,3,opt,name=system_usec,json=systemUsec,proto3" json:"system_usec,omitempty"`
+	// Number of enforcement periods and how many of them were throttled.
+	NrPeriods     uint64 `protobuf:"varint,4,opt,name=nr_periods,json=nrPeriods,proto3" json:"nr_periods,omitempty"`
+	NrThrottled   uint64 `protobuf:"varint,5,opt,name=nr_throttled,json=nrThrottled,proto3" json:"nr_throttled,omitempty"`
+	ThrottledUsec uint64 `protobuf:"varint,6,opt,name=throttled_usec,json=throttledUsec,proto3" json:"throttled_usec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CPUStats) Reset() {
+	*x = CPUStats{}
+	mi := &file_tasker_tasker_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CPUStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CPUStats) ProtoMessage() {}
+
+func (x *CPUStats) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CPUStats.ProtoReflect.Descriptor instead.
+func (*CPUStats) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CPUStats) GetUsageUsec() uint64 {
+	if x != nil {
+		return x.UsageUsec
+	}
+	return 0
+}
+
+func (x *CPUStats) GetUserUsec() uint64 {
+	if x != nil {
+		return x.UserUsec
+	}
+	return 0
+}
+
+func (x *CPUStats) GetSystemUsec() uint64 {
+	if x != nil {
+		return x.SystemUsec
+	}
+	return 0
+}
+
+func (x *CPUStats) GetNrPeriods() uint64 {
+	if x != nil {
+		return x.NrPeriods
+	}
+	return 0
+}
+
+func (x *CPUStats) GetNrThrottled() uint64 {
+	if x != nil {
+		return x.NrThrottled
+	}
+	return 0
+}
+
+func (x *CPUStats) GetThrottledUsec() uint64 {
+	if x != nil {
+		return x.ThrottledUsec
+	}
+	return 0
+}
+
+// MemoryStats holds usage from memory.current, memory.peak and memory.stat in bytes and event counts from
+// memory.events.
+type MemoryStats struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Current uint64                 `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"`
+	// Highest usage recorded (0 if the kernel does not report it).
+	Peak   uint64 `protobuf:"varint,2,opt,name=peak,proto3" json:"peak,omitempty"`
+	Anon   uint64 `protobuf:"varint,3,opt,name=anon,proto3" json:"anon,omitempty"`
+	File   uint64 `protobuf:"varint,4,opt,name=file,proto3" json:"file,omitempty"`
+	Kernel uint64 `protobuf:"varint,5,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	Shmem  uint64 `protobuf:"varint,6,opt,name=shmem,proto3" json:"shmem,omitempty"`
+	Sock   uint64 `protobuf:"varint,7,opt,name=sock,proto3" json:"sock,omitempty"`
+	// Page fault counts.
+	Pgfault    uint64 `protobuf:"varint,8,opt,name=pgfault,proto3" json:"pgfault,omitempty"`
+	Pgmajfault uint64 `protobuf:"varint,9,opt,name=pgmajfault,proto3" json:"pgmajfault,omitempty"`
+	// Number of times memory.max was hit.
+	Oom uint64 `protobuf:"varint,10,opt,name=oom,proto3" json:"oom,omitempty"`
+	// Number of processes killed by the OOM killer.
+	OomKill       uint64 `protobuf:"varint,11,opt,name=oom_kill,json=oomKill,proto3" json:"oom_kill,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryStats) Reset() {
+	*x = MemoryStats{}
+	mi := &file_tasker_tasker_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryStats) ProtoMessage() {}
+
+func (x *MemoryStats) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryStats.ProtoReflect.Descriptor instead.
+func (*MemoryStats) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MemoryStats) GetCurrent() uint64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetPeak() uint64 {
+	if x != nil {
+		return x.Peak
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetAnon() uint64 {
+	if x != nil {
+		return x.Anon
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetFile() uint64 {
+	if x != nil {
+		return x.File
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetKernel() uint64 {
+	if x != nil {
+		return x.Kernel
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetShmem() uint64 {
+	if x != nil {
+		return x.Shmem
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetSock() uint64 {
+	if x != nil {
+		return x.Sock
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetPgfault() uint64 {
+	if x != nil {
+		return x.Pgfault
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetPgmajfault() uint64 {
+	if x != nil {
+		return x.Pgmajfault
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetOom() uint64 {
+	if x != nil {
+		return x.Oom
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetOomKill() uint64 {
+	if x != nil {
+		return x.OomKill
+	}
+	return 0
+}
+
+// IOStats holds usage from io.stat for a block device.
+type IOStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Block device MAJ:MIN.
+	Device        string `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Rbytes        uint64 `protobuf:"varint,2,opt,name=rbytes,proto3" json:"rbytes,omitempty"`
+	Wbytes        uint64 `protobuf:"varint,3,opt,name=wbytes,proto3" json:"wbytes,omitempty"`
+	Rios          uint64 `protobuf:"varint,4,opt,name=rios,proto3" json:"rios,omitempty"`
+	Wios          uint64 `protobuf:"varint,5,opt,name=wios,proto3" json:"wios,omitempty"`
+	Dbytes        uint64 `protobuf:"varint,6,opt,name=dbytes,proto3" json:"dbytes,omitempty"`
+	Dios          uint64 `protobuf:"varint,7,opt,name=dios,proto3" json:"dios,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IOStats) Reset() {
+	*x = IOStats{}
+	mi := &file_tasker_tasker_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IOStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IOStats) ProtoMessage() {}
+
+func (x *IOStats) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IOStats.ProtoReflect.Descriptor instead.
+func (*IOStats) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IOStats) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *IOStats) GetRbytes() uint64 {
+	if x != nil {
+		return x.Rbytes
+	}
+	return 0
+}
+
+func (x *IOStats) GetWbytes() uint64 {
+	if x != nil {
+		return x.Wbytes
+	}
+	return 0
+}
+
+func (x *IOStats) GetRios() uint64 {
+	if x != nil {
+		return x.Rios
+	}
+	return 0
+}
+
+func (x *IOStats) GetWios() uint64 {
+	if x != nil {
+		return x.Wios
+	}
+	return 0
+}
+
+func (x *IOStats) GetDbytes() uint64 {
+	if x != nil {
+		return x.Dbytes
+	}
+	return 0
+}
+
+func (x *IOStats) GetDios() uint64 {
+	if x != nil {
+		return x.Dios
+	}
+	return 0
+}
+
+// PIDsStats holds usage from pids.current.
+type PIDsStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       uint64                 `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PIDsStats) Reset() {
+	*x = PIDsStats{}
+	mi := &file_tasker_tasker_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PIDsStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PIDsStats) ProtoMessage() {}
+
+func (x *PIDsStats) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PIDsStats.ProtoReflect.Descriptor instead.
+func (*PIDsStats) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PIDsStats) GetCurrent() uint64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+// StartJobRequest contains what is needed to create and start a job.
+type StartJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path to the executable.
+	Command string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	// Arguments to the executable.
+	Args []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// Resource limits (optional).
+	Limits *ResourceLimits `protobuf:"bytes,3,opt,name=limits,proto3" json:"limits,omitempty"`
+	// Keep stdin open for SendJobInput (otherwise stdin is /dev/null).
+	Stdin bool `protobuf:"varint,4,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// Run under a pseudo-terminal used for stdin, stdout and stderr (implies stdin).
+	Tty bool `protobuf:"varint,5,opt,name=tty,proto3" json:"tty,omitempty"`
+	// Environment variables applied on top of the base environment.
+	Env map[string]string `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Base environment (defaults to the server's environment).
+	EnvBase EnvBase `protobuf:"varint,7,opt,name=env_base,json=envBase,proto3,enum=tasker.EnvBase" json:"env_base,omitempty"`
+	// Absolute working directory (defaults to the server's working directory).
+	Workdir string `protobuf:"bytes,8,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	// Max runtime in seconds before the job is stopped (optional).
+	Timeout *uint32 `protobuf:"varint,9,opt,name=timeout,proto3,oneof" json:"timeout,omitempty"`
+	// Default signal sent by StopJob and timeouts (e.g. SIGINT, defaults to SIGTERM).
+	StopSignal *string `protobuf:"bytes,10,opt,name=stop_signal,json=stopSignal,proto3,oneof" json:"stop_signal,omitempty"`
+	// Default seconds to wait after the stop signal before the cgroup is killed (defaults to 2).
+	StopGrace     *uint32 `protobuf:"varint,11,opt,name=stop_grace,json=stopGrace,proto3,oneof" json:"stop_grace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartJobRequest) Reset() {
+	*x = StartJobRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartJobRequest) ProtoMessage() {}
+
+func (x *StartJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartJobRequest.ProtoReflect.Descriptor instead.
+func (*StartJobRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StartJobRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetLimits() *ResourceLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetStdin() bool {
+	if x != nil {
+		return x.Stdin
+	}
+	return false
+}
+
+func (x *StartJobRequest) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+func (x *StartJobRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetEnvBase() EnvBase {
+	if x != nil {
+		return x.EnvBase
+	}
+	return EnvBase_ENV_BASE_UNSPECIFIED
+}
+
+func (x *StartJobRequest) GetWorkdir() string {
+	if x != nil {
+		return x.Workdir
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetTimeout() uint32 {
+	if x != nil && x.Timeout != nil {
+		return *x.Timeout
+	}
+	return 0
+}
+
+func (x *StartJobRequest) GetStopSignal() string {
+	if x != nil && x.StopSignal != nil {
+		return *x.StopSignal
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetStopGrace() uint32 {
+	if x != nil && x.StopGrace != nil {
+		return *x.StopGrace
+	}
+	return 0
+}
+
+// StartJobResponse contains the started job.
+type StartJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartJobResponse) Reset() {
+	*x = StartJobResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartJobResponse) ProtoMessage() {}
+
+func (x *StartJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartJobResponse.ProtoReflect.Descriptor instead.
+func (*StartJobResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StartJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// StopJobRequest identifies the job to stop.
+type StopJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Signal sent to the process group (e.g. SIGINT, defaults to the job's stop signal).
+	Signal *string `protobuf:"bytes,2,opt,name=signal,proto3,oneof" json:"signal,omitempty"`
+	// Seconds to wait after the signal before the cgroup is killed (defaults to the job's stop grace).
+	Grace         *uint32 `protobuf:"varint,3,opt,name=grace,proto3,oneof" json:"grace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopJobRequest) Reset() {
+	*x = StopJobRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopJobRequest) ProtoMessage() {}
+
+func (x *StopJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopJobRequest.ProtoReflect.Descriptor instead.
+func (*StopJobRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StopJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StopJobRequest) GetSignal() string {
+	if x != nil && x.Signal != nil {
+		return *x.Signal
+	}
+	return ""
+}
+
+func (x *StopJobRequest) GetGrace() uint32 {
+	if x != nil && x.Grace != nil {
+		return *x.Grace
+	}
+	return 0
+}
+
+// StopJobResponse contains the stopped job.
+type StopJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopJobResponse) Reset() {
+	*x = StopJobResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopJobResponse) ProtoMessage() {}
+
+func (x *StopJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopJobResponse.ProtoReflect.Descriptor instead.
+func (*StopJobResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StopJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// GetJobRequest identifies the job to get.
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// GetJobResponse contains the requested job.
+type GetJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// ListJobsRequest filters and paginates jobs.
+//
+// Jobs are returned oldest first. Users only see their own jobs; admins see all jobs.
+type ListJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only jobs owned by this user (optional).
+	Owner *string `protobuf:"bytes,1,opt,name=owner,proto3,oneof" json:"owner,omitempty"`
+	// Only jobs in this phase (optional).
+	Phase JobPhase `protobuf:"varint,2,opt,name=phase,proto3,enum=tasker.JobPhase" json:"phase,omitempty"`
+	// Only jobs whose command line contains this substring (optional).
+	Command string `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	// Only jobs created at or after this time (optional).
+	CreatedAfter *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// Only jobs created before this time (optional).
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Max number of jobs to return (default 100, max 1000).
+	PageSize uint32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous ListJobsResponse to continue from.
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListJobsRequest) GetOwner() string {
+	if x != nil && x.Owner != nil {
+		return *x.Owner
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetPhase() JobPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return JobPhase_JOB_PHASE_UNSPECIFIED
+}
+
+func (x *ListJobsRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListJobsResponse contains a page of jobs.
+type ListJobsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Jobs  []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// Token for the next page (empty when there are no more jobs).
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListJobsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// AttachJobRequest identifies the job to attach to and where to start reading its output.
+//
+// Only one of since, offset, tail_bytes and tail_lines can be set. With none of them reading starts at the beginning.
+type AttachJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Only send output written to this stream (unspecified sends both).
+	Stream OutputStream `protobuf:"varint,2,opt,name=stream,proto3,enum=tasker.OutputStream" json:"stream,omitempty"`
+	// Start at the first output written at or after this time.
+	Since *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	// Start at this byte of the output, counting every byte ever written (e.g. a response's offset plus its data length
+	// to resume).
+	Offset uint64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Start this many bytes before the end of the output.
+	TailBytes uint64 `protobuf:"varint,5,opt,name=tail_bytes,json=tailBytes,proto3" json:"tail_bytes,omitempty"`
+	// Start this many lines before the end of the output.
+	TailLines uint64 `protobuf:"varint,6,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"`
+	// Stop at the end of the output written when the request arrives instead of waiting for more.
+	NoFollow      bool `protobuf:"varint,7,opt,name=no_follow,json=noFollow,proto3" json:"no_follow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachJobRequest) Reset() {
+	*x = AttachJobRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachJobRequest) ProtoMessage() {}
+
+func (x *AttachJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachJobRequest.ProtoReflect.Descriptor instead.
+func (*AttachJobRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AttachJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AttachJobRequest) GetStream() OutputStream {
+	if x != nil {
+		return x.Stream
+	}
+	return OutputStream_OUTPUT_STREAM_UNSPECIFIED
+}
+
+func (x *AttachJobRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *AttachJobRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AttachJobRequest) GetTailBytes() uint64 {
+	if x != nil {
+		return x.TailBytes
+	}
+	return 0
+}
+
+func (x *AttachJobRequest) GetTailLines() uint64 {
+	if x != nil {
+		return x.TailLines
+	}
+	return 0
+}
+
+func (x *AttachJobRequest) GetNoFollow() bool {
+	if x != nil {
+		return x.NoFollow
+	}
+	return false
+}
+
+// AttachJobResponse is output data from the requested job.
+type AttachJobResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Raw output bytes from the job's stdout/stderr.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Bytes the reader missed because they were dropped over the server's output limit (data is empty when set).
+	Truncated uint64 `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Stream data was written to.
+	Stream OutputStream `protobuf:"varint,3,opt,name=stream,proto3,enum=tasker.OutputStream" json:"stream,omitempty"`
+	// Time data arrived at the server.
+	Time *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// Offset of data's first byte in the output, or where reading continues when truncated is set.
+	Offset        uint64 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachJobResponse) Reset() {
+	*x = AttachJobResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachJobResponse) ProtoMessage() {}
+
+func (x *AttachJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachJobResponse.ProtoReflect.Descriptor instead.
+func (*AttachJobResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AttachJobResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *AttachJobResponse) GetTruncated() uint64 {
+	if x != nil {
+		return x.Truncated
+	}
+	return 0
+}
+
+func (x *AttachJobResponse) GetStream() OutputStream {
+	if x != nil {
+		return x.Stream
+	}
+	return OutputStream_OUTPUT_STREAM_UNSPECIFIED
+}
+
+func (x *AttachJobResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AttachJobResponse) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// SendJobInputRequest is input for the job's stdin.
+type SendJobInputRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Job ID (only read from the first message).
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Input:
+	//
+	//	*SendJobInputRequest_Data
+	//	*SendJobInputRequest_Close
+	//	*SendJobInputRequest_Resize
+	Input         isSendJobInputRequest_Input `protobuf_oneof:"input"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendJobInputRequest) Reset() {
+	*x = SendJobInputRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendJobInputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendJobInputRequest) ProtoMessage() {}
+
+func (x *SendJobInputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendJobInputRequest.ProtoReflect.Descriptor instead.
+func (*SendJobInputRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SendJobInputRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SendJobInputRequest) GetInput() isSendJobInputRequest_Input {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *SendJobInputRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Input.(*SendJobInputRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *SendJobInputRequest) GetClose() bool {
+	if x != nil {
+		if x, ok := x.Input.(*SendJobInputRequest_Close); ok {
+			return x.Close
+		}
+	}
+	return false
+}
+
+func (x *SendJobInputRequest) GetResize() *WindowSize {
+	if x != nil {
+		if x, ok := x.Input.(*SendJobInputRequest_Resize); ok {
+			return x.Resize
+		}
+	}
+	return nil
+}
+
+type isSendJobInputRequest_Input interface {
+	isSendJobInputRequest_Input()
+}
+
+type SendJobInputRequest_Data struct {
+	// Raw bytes to write to stdin.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+type SendJobInputRequest_Close struct {
+	// Close stdin so the job reads EOF (sends the EOF character under a terminal).
+	Close bool `protobuf:"varint,3,opt,name=close,proto3,oneof"`
+}
+
+type SendJobInputRequest_Resize struct {
+	// Resize the job's terminal.
+	Resize *WindowSize `protobuf:"bytes,4,opt,name=resize,proto3,oneof"`
+}
+
+func (*SendJobInputRequest_Data) isSendJobInputRequest_Input() {}
+
+func (*SendJobInputRequest_Close) isSendJobInputRequest_Input() {}
+
+func (*SendJobInputRequest_Resize) isSendJobInputRequest_Input() {}
+
+// WindowSize is the size of a terminal.
+type WindowSize struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          uint32                 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols          uint32                 `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WindowSize) Reset() {
+	*x = WindowSize{}
+	mi := &file_tasker_tasker_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowSize) ProtoMessage() {}
+
+func (x *WindowSize) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowSize.ProtoReflect.Descriptor instead.
+func (*WindowSize) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WindowSize) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *WindowSize) GetCols() uint32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+// SendJobInputResponse is sent once the input stream is closed.
+type SendJobInputResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Total bytes written to stdin.
+	Written       uint64 `protobuf:"varint,1,opt,name=written,proto3" json:"written,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendJobInputResponse) Reset() {
+	*x = SendJobInputResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendJobInputResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendJobInputResponse) ProtoMessage() {}
+
+func (x *SendJobInputResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendJobInputResponse.ProtoReflect.Descriptor instead.
+func (*SendJobInputResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SendJobInputResponse) GetWritten() uint64 {
+	if x != nil {
+		return x.Written
+	}
+	return 0
+}
+
+// SignalJobRequest identifies the job and the signal to send.
+type SignalJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Signal name or number (e.g. SIGHUP, HUP or 1).
+	Signal string `protobuf:"bytes,2,opt,name=signal,proto3" json:"signal,omitempty"`
+	// Send to every process in the job's cgroup instead of the process group.
+	All           bool `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalJobRequest) Reset() {
+	*x = SignalJobRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalJobRequest) ProtoMessage() {}
+
+func (x *SignalJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalJobRequest.ProtoReflect.Descriptor instead.
+func (*SignalJobRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SignalJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SignalJobRequest) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *SignalJobRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+// SignalJobResponse contains the signaled job.
+type SignalJobResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Job   *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// Number of processes the signal was sent to (0 when sent to the process group).
+	Signaled      uint32 `protobuf:"varint,2,opt,name=signaled,proto3" json:"signaled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalJobResponse) Reset() {
+	*x = SignalJobResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalJobResponse) ProtoMessage() {}
+
+func (x *SignalJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalJobResponse.ProtoReflect.Descriptor instead.
+func (*SignalJobResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SignalJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
 	}
-	return 0
+	return nil
 }
 
-func (x *IOLimits) GetWrite() uint32 {
-	if x != nil && x.Write != nil {
-		return *x.Write
+func (x *SignalJobResponse) GetSignaled() uint32 {
+	if x != nil {
+		return x.Signaled
 	}
 	return 0
 }
 
-// Job represents a managed process.
-type Job struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Unique ID.
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// User who started this job.
-	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
-	// Path to the executable.
-	Command string `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
-	// Arguments to the executable.
-	Args []string `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
-	// Current lifecycle phase.
-	Phase JobPhase `protobuf:"varint,5,opt,name=phase,proto3,enum=tasker.JobPhase" json:"phase,omitempty"`
-	// Resource limits (optional).
-	Limits        *ResourceLimits `protobuf:"bytes,6,opt,name=limits,proto3" json:"limits,omitempty"`
+// PauseJobRequest identifies the job to pause.
+type PauseJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Job) Reset() {
-	*x = Job{}
-	mi := &file_tasker_tasker_proto_msgTypes[2]
+func (x *PauseJobRequest) Reset() {
+	*x = PauseJobRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Job) String() string {
+func (x *PauseJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Job) ProtoMessage() {}
+func (*PauseJobRequest) ProtoMessage() {}
 
-func (x *Job) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[2]
+func (x *PauseJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,81 +2416,86 @@ func (x *Job) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Job.ProtoReflect.Descriptor instead.
-func (*Job) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use PauseJobRequest.ProtoReflect.Descriptor instead.
+func (*PauseJobRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *Job) GetId() string {
+func (x *PauseJobRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Job) GetOwner() string {
-	if x != nil {
-		return x.Owner
-	}
-	return ""
+// PauseJobResponse contains the paused job.
+type PauseJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Job) GetCommand() string {
-	if x != nil {
-		return x.Command
-	}
-	return ""
+func (x *PauseJobResponse) Reset() {
+	*x = PauseJobResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Job) GetArgs() []string {
-	if x != nil {
-		return x.Args
-	}
-	return nil
+func (x *PauseJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Job) GetPhase() JobPhase {
+func (*PauseJobResponse) ProtoMessage() {}
+
+func (x *PauseJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[26]
 	if x != nil {
-		return x.Phase
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return JobPhase_JOB_PHASE_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *Job) GetLimits() *ResourceLimits {
+// Deprecated: Use PauseJobResponse.ProtoReflect.Descriptor instead.
+func (*PauseJobResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PauseJobResponse) GetJob() *Job {
 	if x != nil {
-		return x.Limits
+		return x.Job
 	}
 	return nil
 }
 
-// StartJobRequest contains what is needed to create and start a job.
-type StartJobRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Path to the executable.
-	Command string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
-	// Arguments to the executable.
-	Args []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
-	// Resource limits (optional).
-	Limits        *ResourceLimits `protobuf:"bytes,3,opt,name=limits,proto3" json:"limits,omitempty"`
+// ResumeJobRequest identifies the job to resume.
+type ResumeJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartJobRequest) Reset() {
-	*x = StartJobRequest{}
-	mi := &file_tasker_tasker_proto_msgTypes[3]
+func (x *ResumeJobRequest) Reset() {
+	*x = ResumeJobRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartJobRequest) String() string {
+func (x *ResumeJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartJobRequest) ProtoMessage() {}
+func (*ResumeJobRequest) ProtoMessage() {}
 
-func (x *StartJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[3]
+func (x *ResumeJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,55 +2506,41 @@ func (x *StartJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartJobRequest.ProtoReflect.Descriptor instead.
-func (*StartJobRequest) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ResumeJobRequest.ProtoReflect.Descriptor instead.
+func (*ResumeJobRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *StartJobRequest) GetCommand() string {
+func (x *ResumeJobRequest) GetId() string {
 	if x != nil {
-		return x.Command
+		return x.Id
 	}
 	return ""
 }
 
-func (x *StartJobRequest) GetArgs() []string {
-	if x != nil {
-		return x.Args
-	}
-	return nil
-}
-
-func (x *StartJobRequest) GetLimits() *ResourceLimits {
-	if x != nil {
-		return x.Limits
-	}
-	return nil
-}
-
-// StartJobResponse contains the started job.
-type StartJobResponse struct {
+// ResumeJobResponse contains the resumed job.
+type ResumeJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartJobResponse) Reset() {
-	*x = StartJobResponse{}
-	mi := &file_tasker_tasker_proto_msgTypes[4]
+func (x *ResumeJobResponse) Reset() {
+	*x = ResumeJobResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartJobResponse) String() string {
+func (x *ResumeJobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartJobResponse) ProtoMessage() {}
+func (*ResumeJobResponse) ProtoMessage() {}
 
-func (x *StartJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[4]
+func (x *ResumeJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -394,41 +2551,45 @@ func (x *StartJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartJobResponse.ProtoReflect.Descriptor instead.
-func (*StartJobResponse) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ResumeJobResponse.ProtoReflect.Descriptor instead.
+func (*ResumeJobResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *StartJobResponse) GetJob() *Job {
+func (x *ResumeJobResponse) GetJob() *Job {
 	if x != nil {
 		return x.Job
 	}
 	return nil
 }
 
-// StopJobRequest identifies the job to stop.
-type StopJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+// UpdateJobLimitsRequest identifies the job and its new limits.
+type UpdateJobLimitsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Limits to change (unset limits are left as they are, io replaces the current io limits).
+	Limits *ResourceLimits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	// Lift all of the current io limits (cannot be combined with io in limits).
+	ClearIo       bool `protobuf:"varint,3,opt,name=clear_io,json=clearIo,proto3" json:"clear_io,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopJobRequest) Reset() {
-	*x = StopJobRequest{}
-	mi := &file_tasker_tasker_proto_msgTypes[5]
+func (x *UpdateJobLimitsRequest) Reset() {
+	*x = UpdateJobLimitsRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopJobRequest) String() string {
+func (x *UpdateJobLimitsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopJobRequest) ProtoMessage() {}
+func (*UpdateJobLimitsRequest) ProtoMessage() {}
 
-func (x *StopJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[5]
+func (x *UpdateJobLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -439,41 +2600,55 @@ func (x *StopJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopJobRequest.ProtoReflect.Descriptor instead.
-func (*StopJobRequest) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use UpdateJobLimitsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateJobLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *StopJobRequest) GetId() string {
+func (x *UpdateJobLimitsRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-// StopJobResponse contains the stopped job.
-type StopJobResponse struct {
+func (x *UpdateJobLimitsRequest) GetLimits() *ResourceLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *UpdateJobLimitsRequest) GetClearIo() bool {
+	if x != nil {
+		return x.ClearIo
+	}
+	return false
+}
+
+// UpdateJobLimitsResponse contains the job with its updated limits.
+type UpdateJobLimitsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopJobResponse) Reset() {
-	*x = StopJobResponse{}
-	mi := &file_tasker_tasker_proto_msgTypes[6]
+func (x *UpdateJobLimitsResponse) Reset() {
+	*x = UpdateJobLimitsResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopJobResponse) String() string {
+func (x *UpdateJobLimitsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopJobResponse) ProtoMessage() {}
+func (*UpdateJobLimitsResponse) ProtoMessage() {}
 
-func (x *StopJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[6]
+func (x *UpdateJobLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -484,41 +2659,41 @@ func (x *StopJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopJobResponse.ProtoReflect.Descriptor instead.
-func (*StopJobResponse) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UpdateJobLimitsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateJobLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *StopJobResponse) GetJob() *Job {
+func (x *UpdateJobLimitsResponse) GetJob() *Job {
 	if x != nil {
 		return x.Job
 	}
 	return nil
 }
 
-// GetJobRequest identifies the job to get.
-type GetJobRequest struct {
+// GetJobStatsRequest identifies the job to sample.
+type GetJobStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobRequest) Reset() {
-	*x = GetJobRequest{}
-	mi := &file_tasker_tasker_proto_msgTypes[7]
+func (x *GetJobStatsRequest) Reset() {
+	*x = GetJobStatsRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobRequest) String() string {
+func (x *GetJobStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobRequest) ProtoMessage() {}
+func (*GetJobStatsRequest) ProtoMessage() {}
 
-func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[7]
+func (x *GetJobStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,41 +2704,41 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
-func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetJobStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobStatsRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *GetJobRequest) GetId() string {
+func (x *GetJobStatsRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-// GetJobResponse contains the requested job.
-type GetJobResponse struct {
+// GetJobStatsResponse contains the job's resource usage.
+type GetJobStatsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Stats         *JobStats              `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobResponse) Reset() {
-	*x = GetJobResponse{}
-	mi := &file_tasker_tasker_proto_msgTypes[8]
+func (x *GetJobStatsResponse) Reset() {
+	*x = GetJobStatsResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobResponse) String() string {
+func (x *GetJobStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobResponse) ProtoMessage() {}
+func (*GetJobStatsResponse) ProtoMessage() {}
 
-func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[8]
+func (x *GetJobStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -574,41 +2749,43 @@ func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
-func (*GetJobResponse) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetJobStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetJobStatsResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *GetJobResponse) GetJob() *Job {
+func (x *GetJobStatsResponse) GetStats() *JobStats {
 	if x != nil {
-		return x.Job
+		return x.Stats
 	}
 	return nil
 }
 
-// AttachJobRequest identifies the job to attach to.
-type AttachJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+// WatchJobStatsRequest identifies the job to sample and how often.
+type WatchJobStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Milliseconds between samples (defaults to 1000, min 100).
+	IntervalMs    *uint32 `protobuf:"varint,2,opt,name=interval_ms,json=intervalMs,proto3,oneof" json:"interval_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttachJobRequest) Reset() {
-	*x = AttachJobRequest{}
-	mi := &file_tasker_tasker_proto_msgTypes[9]
+func (x *WatchJobStatsRequest) Reset() {
+	*x = WatchJobStatsRequest{}
+	mi := &file_tasker_tasker_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttachJobRequest) String() string {
+func (x *WatchJobStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttachJobRequest) ProtoMessage() {}
+func (*WatchJobStatsRequest) ProtoMessage() {}
 
-func (x *AttachJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[9]
+func (x *WatchJobStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -619,42 +2796,48 @@ func (x *AttachJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttachJobRequest.ProtoReflect.Descriptor instead.
-func (*AttachJobRequest) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use WatchJobStatsRequest.ProtoReflect.Descriptor instead.
+func (*WatchJobStatsRequest) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *AttachJobRequest) GetId() string {
+func (x *WatchJobStatsRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-// AttachJobResponse is output data from the requested job.
-type AttachJobResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Raw output bytes from the job's stdout/stderr.
-	Data          []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+func (x *WatchJobStatsRequest) GetIntervalMs() uint32 {
+	if x != nil && x.IntervalMs != nil {
+		return *x.IntervalMs
+	}
+	return 0
+}
+
+// WatchJobStatsResponse contains a sample of the job's resource usage.
+type WatchJobStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *JobStats              `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttachJobResponse) Reset() {
-	*x = AttachJobResponse{}
-	mi := &file_tasker_tasker_proto_msgTypes[10]
+func (x *WatchJobStatsResponse) Reset() {
+	*x = WatchJobStatsResponse{}
+	mi := &file_tasker_tasker_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttachJobResponse) String() string {
+func (x *WatchJobStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttachJobResponse) ProtoMessage() {}
+func (*WatchJobStatsResponse) ProtoMessage() {}
 
-func (x *AttachJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tasker_tasker_proto_msgTypes[10]
+func (x *WatchJobStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasker_tasker_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -665,14 +2848,14 @@ func (x *AttachJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttachJobResponse.ProtoReflect.Descriptor instead.
-func (*AttachJobResponse) Descriptor() ([]byte, []int) {
-	return file_tasker_tasker_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use WatchJobStatsResponse.ProtoReflect.Descriptor instead.
+func (*WatchJobStatsResponse) Descriptor() ([]byte, []int) {
+	return file_tasker_tasker_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *AttachJobResponse) GetData() []byte {
+func (x *WatchJobStatsResponse) GetStats() *JobStats {
 	if x != nil {
-		return x.Data
+		return x.Stats
 	}
 	return nil
 }
@@ -681,55 +2864,287 @@ var File_tasker_tasker_proto protoreflect.FileDescriptor
 
 const file_tasker_tasker_proto_rawDesc = "" +
 	"\n" +
-	"\x13tasker/tasker.proto\x12\x06tasker\"\x85\x01\n" +
+	"\x13tasker/tasker.proto\x12\x06tasker\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd8\x04\n" +
 	"\x0eResourceLimits\x12\x15\n" +
 	"\x03cpu\x18\x01 \x01(\x02H\x00R\x03cpu\x88\x01\x01\x12\x1b\n" +
-	"\x06memory\x18\x02 \x01(\rH\x01R\x06memory\x88\x01\x01\x12%\n" +
-	"\x02io\x18\x03 \x01(\v2\x10.tasker.IOLimitsH\x02R\x02io\x88\x01\x01B\x06\n" +
+	"\x06memory\x18\x02 \x01(\rH\x01R\x06memory\x88\x01\x01\x12 \n" +
+	"\x02io\x18\x03 \x03(\v2\x10.tasker.IOLimitsR\x02io\x12\x17\n" +
+	"\x04pids\x18\x04 \x01(\rH\x02R\x04pids\x88\x01\x01\x12 \n" +
+	"\toom_group\x18\x05 \x01(\bH\x03R\boomGroup\x88\x01\x01\x12$\n" +
+	"\vmemory_high\x18\x06 \x01(\rH\x04R\n" +
+	"memoryHigh\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"memory_low\x18\a \x01(\rH\x05R\tmemoryLow\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"memory_min\x18\b \x01(\rH\x06R\tmemoryMin\x88\x01\x01\x12\x17\n" +
+	"\x04swap\x18\t \x01(\rH\aR\x04swap\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"cpu_weight\x18\n" +
+	" \x01(\rH\bR\tcpuWeight\x88\x01\x01\x12 \n" +
+	"\tcpu_burst\x18\v \x01(\x02H\tR\bcpuBurst\x88\x01\x01\x12$\n" +
+	"\vcpuset_cpus\x18\f \x01(\tH\n" +
+	"R\n" +
+	"cpusetCpus\x88\x01\x01\x12$\n" +
+	"\vcpuset_mems\x18\r \x01(\tH\vR\n" +
+	"cpusetMems\x88\x01\x01B\x06\n" +
 	"\x04_cpuB\t\n" +
-	"\a_memoryB\x05\n" +
-	"\x03_io\"i\n" +
+	"\a_memoryB\a\n" +
+	"\x05_pidsB\f\n" +
+	"\n" +
+	"_oom_groupB\x0e\n" +
+	"\f_memory_highB\r\n" +
+	"\v_memory_lowB\r\n" +
+	"\v_memory_minB\a\n" +
+	"\x05_swapB\r\n" +
+	"\v_cpu_weightB\f\n" +
+	"\n" +
+	"_cpu_burstB\x0e\n" +
+	"\f_cpuset_cpusB\x0e\n" +
+	"\f_cpuset_mems\"\x9f\x02\n" +
 	"\bIOLimits\x12\x16\n" +
 	"\x06device\x18\x01 \x01(\tR\x06device\x12\x17\n" +
 	"\x04read\x18\x02 \x01(\rH\x00R\x04read\x88\x01\x01\x12\x19\n" +
-	"\x05write\x18\x03 \x01(\rH\x01R\x05write\x88\x01\x01B\a\n" +
+	"\x05write\x18\x03 \x01(\rH\x01R\x05write\x88\x01\x01\x12 \n" +
+	"\tread_iops\x18\x04 \x01(\rH\x02R\breadIops\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"write_iops\x18\x05 \x01(\rH\x03R\twriteIops\x88\x01\x01\x12\x1b\n" +
+	"\x06weight\x18\x06 \x01(\rH\x04R\x06weight\x88\x01\x01\x12\x1d\n" +
+	"\alatency\x18\a \x01(\rH\x05R\alatency\x88\x01\x01B\a\n" +
 	"\x05_readB\b\n" +
-	"\x06_write\"\xb1\x01\n" +
+	"\x06_writeB\f\n" +
+	"\n" +
+	"_read_iopsB\r\n" +
+	"\v_write_iopsB\t\n" +
+	"\a_weightB\n" +
+	"\n" +
+	"\b_latency\"\x85\x01\n" +
+	"\n" +
+	"ExitStatus\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x16\n" +
+	"\x06signal\x18\x02 \x01(\tR\x06signal\x12\x1f\n" +
+	"\vcore_dumped\x18\x03 \x01(\bR\n" +
+	"coreDumped\x12*\n" +
+	"\x06reason\x18\x04 \x01(\x0e2\x12.tasker.ExitReasonR\x06reason\"\x99\x01\n" +
+	"\x0fPhaseTransition\x12$\n" +
+	"\x04from\x18\x01 \x01(\x0e2\x10.tasker.JobPhaseR\x04from\x12 \n" +
+	"\x02to\x18\x02 \x01(\x0e2\x10.tasker.JobPhaseR\x02to\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x0e\n" +
+	"\x02by\x18\x04 \x01(\tR\x02by\"\x96\x06\n" +
 	"\x03Job\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x18\n" +
 	"\acommand\x18\x03 \x01(\tR\acommand\x12\x12\n" +
 	"\x04args\x18\x04 \x03(\tR\x04args\x12&\n" +
 	"\x05phase\x18\x05 \x01(\x0e2\x10.tasker.JobPhaseR\x05phase\x12.\n" +
-	"\x06limits\x18\x06 \x01(\v2\x16.tasker.ResourceLimitsR\x06limits\"o\n" +
+	"\x06limits\x18\x06 \x01(\v2\x16.tasker.ResourceLimitsR\x06limits\x12&\n" +
+	"\x04exit\x18\a \x01(\v2\x12.tasker.ExitStatusR\x04exit\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x129\n" +
+	"\vtransitions\x18\f \x03(\v2\x17.tasker.PhaseTransitionR\vtransitions\x12&\n" +
+	"\x0fpids_max_events\x18\r \x01(\x04R\rpidsMaxEvents\x12\x10\n" +
+	"\x03tty\x18\x0e \x01(\bR\x03tty\x12\x18\n" +
+	"\aworkdir\x18\x0f \x01(\tR\aworkdir\x12\x10\n" +
+	"\x03uid\x18\x10 \x01(\rR\x03uid\x12\x10\n" +
+	"\x03gid\x18\x11 \x01(\rR\x03gid\x12\x16\n" +
+	"\x06groups\x18\x12 \x03(\rR\x06groups\x12\x18\n" +
+	"\atimeout\x18\x13 \x01(\rR\atimeout\x12\x1f\n" +
+	"\vstop_signal\x18\x14 \x01(\tR\n" +
+	"stopSignal\x12\x1d\n" +
+	"\n" +
+	"stop_grace\x18\x15 \x01(\rR\tstopGrace\x12&\n" +
+	"\x05usage\x18\x16 \x01(\v2\x10.tasker.JobStatsR\x05usage\x12%\n" +
+	"\x0eoutput_dropped\x18\x17 \x01(\x04R\routputDropped\"\xe9\x01\n" +
+	"\bJobStats\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\"\n" +
+	"\x03cpu\x18\x02 \x01(\v2\x10.tasker.CPUStatsR\x03cpu\x12+\n" +
+	"\x06memory\x18\x03 \x01(\v2\x13.tasker.MemoryStatsR\x06memory\x12\x1f\n" +
+	"\x02io\x18\x04 \x03(\v2\x0f.tasker.IOStatsR\x02io\x12%\n" +
+	"\x04pids\x18\x05 \x01(\v2\x11.tasker.PIDsStatsR\x04pids\x12\x14\n" +
+	"\x05final\x18\x06 \x01(\bR\x05final\"\xd0\x01\n" +
+	"\bCPUStats\x12\x1d\n" +
+	"\n" +
+	"usage_usec\x18\x01 \x01(\x04R\tusageUsec\x12\x1b\n" +
+	"\tuser_usec\x18\x02 \x01(\x04R\buserUsec\x12\x1f\n" +
+	"\vsystem_usec\x18\x03 \x01(\x04R\n" +
+	"systemUsec\x12\x1d\n" +
+	"\n" +
+	"nr_periods\x18\x04 \x01(\x04R\tnrPeriods\x12!\n" +
+	"\fnr_throttled\x18\x05 \x01(\x04R\vnrThrottled\x12%\n" +
+	"\x0ethrottled_usec\x18\x06 \x01(\x04R\rthrottledUsec\"\x8c\x02\n" +
+	"\vMemoryStats\x12\x18\n" +
+	"\acurrent\x18\x01 \x01(\x04R\acurrent\x12\x12\n" +
+	"\x04peak\x18\x02 \x01(\x04R\x04peak\x12\x12\n" +
+	"\x04anon\x18\x03 \x01(\x04R\x04anon\x12\x12\n" +
+	"\x04file\x18\x04 \x01(\x04R\x04file\x12\x16\n" +
+	"\x06kernel\x18\x05 \x01(\x04R\x06kernel\x12\x14\n" +
+	"\x05shmem\x18\x06 \x01(\x04R\x05shmem\x12\x12\n" +
+	"\x04sock\x18\a \x01(\x04R\x04sock\x12\x18\n" +
+	"\apgfault\x18\b \x01(\x04R\apgfault\x12\x1e\n" +
+	"\n" +
+	"pgmajfault\x18\t \x01(\x04R\n" +
+	"pgmajfault\x12\x10\n" +
+	"\x03oom\x18\n" +
+	" \x01(\x04R\x03oom\x12\x19\n" +
+	"\boom_kill\x18\v \x01(\x04R\aoomKill\"\xa5\x01\n" +
+	"\aIOStats\x12\x16\n" +
+	"\x06device\x18\x01 \x01(\tR\x06device\x12\x16\n" +
+	"\x06rbytes\x18\x02 \x01(\x04R\x06rbytes\x12\x16\n" +
+	"\x06wbytes\x18\x03 \x01(\x04R\x06wbytes\x12\x12\n" +
+	"\x04rios\x18\x04 \x01(\x04R\x04rios\x12\x12\n" +
+	"\x04wios\x18\x05 \x01(\x04R\x04wios\x12\x16\n" +
+	"\x06dbytes\x18\x06 \x01(\x04R\x06dbytes\x12\x12\n" +
+	"\x04dios\x18\a \x01(\x04R\x04dios\"%\n" +
+	"\tPIDsStats\x12\x18\n" +
+	"\acurrent\x18\x01 \x01(\x04R\acurrent\"\xdd\x03\n" +
 	"\x0fStartJobRequest\x12\x18\n" +
 	"\acommand\x18\x01 \x01(\tR\acommand\x12\x12\n" +
 	"\x04args\x18\x02 \x03(\tR\x04args\x12.\n" +
-	"\x06limits\x18\x03 \x01(\v2\x16.tasker.ResourceLimitsR\x06limits\"1\n" +
+	"\x06limits\x18\x03 \x01(\v2\x16.tasker.ResourceLimitsR\x06limits\x12\x14\n" +
+	"\x05stdin\x18\x04 \x01(\bR\x05stdin\x12\x10\n" +
+	"\x03tty\x18\x05 \x01(\bR\x03tty\x122\n" +
+	"\x03env\x18\x06 \x03(\v2 .tasker.StartJobRequest.EnvEntryR\x03env\x12*\n" +
+	"\benv_base\x18\a \x01(\x0e2\x0f.tasker.EnvBaseR\aenvBase\x12\x18\n" +
+	"\aworkdir\x18\b \x01(\tR\aworkdir\x12\x1d\n" +
+	"\atimeout\x18\t \x01(\rH\x00R\atimeout\x88\x01\x01\x12$\n" +
+	"\vstop_signal\x18\n" +
+	" \x01(\tH\x01R\n" +
+	"stopSignal\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"stop_grace\x18\v \x01(\rH\x02R\tstopGrace\x88\x01\x01\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\n" +
+	"\n" +
+	"\b_timeoutB\x0e\n" +
+	"\f_stop_signalB\r\n" +
+	"\v_stop_grace\"1\n" +
 	"\x10StartJobResponse\x12\x1d\n" +
-	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\" \n" +
+	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\"m\n" +
 	"\x0eStopJobRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\x06signal\x18\x02 \x01(\tH\x00R\x06signal\x88\x01\x01\x12\x19\n" +
+	"\x05grace\x18\x03 \x01(\rH\x01R\x05grace\x88\x01\x01B\t\n" +
+	"\a_signalB\b\n" +
+	"\x06_grace\"0\n" +
 	"\x0fStopJobResponse\x12\x1d\n" +
 	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\"\x1f\n" +
 	"\rGetJobRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
 	"\x0eGetJobResponse\x12\x1d\n" +
-	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\"\"\n" +
+	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\"\xb8\x02\n" +
+	"\x0fListJobsRequest\x12\x19\n" +
+	"\x05owner\x18\x01 \x01(\tH\x00R\x05owner\x88\x01\x01\x12&\n" +
+	"\x05phase\x18\x02 \x01(\x0e2\x10.tasker.JobPhaseR\x05phase\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12?\n" +
+	"\rcreated_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageTokenB\b\n" +
+	"\x06_owner\"[\n" +
+	"\x10ListJobsResponse\x12\x1f\n" +
+	"\x04jobs\x18\x01 \x03(\v2\v.tasker.JobR\x04jobs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf5\x01\n" +
 	"\x10AttachJobRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x06stream\x18\x02 \x01(\x0e2\x14.tasker.OutputStreamR\x06stream\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x04R\x06offset\x12\x1d\n" +
+	"\n" +
+	"tail_bytes\x18\x05 \x01(\x04R\ttailBytes\x12\x1d\n" +
+	"\n" +
+	"tail_lines\x18\x06 \x01(\x04R\ttailLines\x12\x1b\n" +
+	"\tno_follow\x18\a \x01(\bR\bnoFollow\"\xbb\x01\n" +
 	"\x11AttachJobResponse\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data*l\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\x04R\ttruncated\x12,\n" +
+	"\x06stream\x18\x03 \x01(\x0e2\x14.tasker.OutputStreamR\x06stream\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x04R\x06offset\"\x8a\x01\n" +
+	"\x13SendJobInputRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04data\x12\x16\n" +
+	"\x05close\x18\x03 \x01(\bH\x00R\x05close\x12,\n" +
+	"\x06resize\x18\x04 \x01(\v2\x12.tasker.WindowSizeH\x00R\x06resizeB\a\n" +
+	"\x05input\"4\n" +
+	"\n" +
+	"WindowSize\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\rR\x04rows\x12\x12\n" +
+	"\x04cols\x18\x02 \x01(\rR\x04cols\"0\n" +
+	"\x14SendJobInputResponse\x12\x18\n" +
+	"\awritten\x18\x01 \x01(\x04R\awritten\"L\n" +
+	"\x10SignalJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06signal\x18\x02 \x01(\tR\x06signal\x12\x10\n" +
+	"\x03all\x18\x03 \x01(\bR\x03all\"N\n" +
+	"\x11SignalJobResponse\x12\x1d\n" +
+	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\x12\x1a\n" +
+	"\bsignaled\x18\x02 \x01(\rR\bsignaled\"!\n" +
+	"\x0fPauseJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
+	"\x10PauseJobResponse\x12\x1d\n" +
+	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\"\"\n" +
+	"\x10ResumeJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x11ResumeJobResponse\x12\x1d\n" +
+	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\"s\n" +
+	"\x16UpdateJobLimitsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x06limits\x18\x02 \x01(\v2\x16.tasker.ResourceLimitsR\x06limits\x12\x19\n" +
+	"\bclear_io\x18\x03 \x01(\bR\aclearIo\"8\n" +
+	"\x17UpdateJobLimitsResponse\x12\x1d\n" +
+	"\x03job\x18\x01 \x01(\v2\v.tasker.JobR\x03job\"$\n" +
+	"\x12GetJobStatsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x13GetJobStatsResponse\x12&\n" +
+	"\x05stats\x18\x01 \x01(\v2\x10.tasker.JobStatsR\x05stats\"\\\n" +
+	"\x14WatchJobStatsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\vinterval_ms\x18\x02 \x01(\rH\x00R\n" +
+	"intervalMs\x88\x01\x01B\x0e\n" +
+	"\f_interval_ms\"?\n" +
+	"\x15WatchJobStatsResponse\x12&\n" +
+	"\x05stats\x18\x01 \x01(\v2\x10.tasker.JobStatsR\x05stats*\x9b\x01\n" +
 	"\bJobPhase\x12\x19\n" +
 	"\x15JOB_PHASE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11JOB_PHASE_RUNNING\x10\x01\x12\x15\n" +
 	"\x11JOB_PHASE_STOPPED\x10\x02\x12\x17\n" +
-	"\x13JOB_PHASE_COMPLETED\x10\x032\x87\x02\n" +
+	"\x13JOB_PHASE_COMPLETED\x10\x03\x12\x17\n" +
+	"\x13JOB_PHASE_TIMED_OUT\x10\x04\x12\x14\n" +
+	"\x10JOB_PHASE_PAUSED\x10\x05*w\n" +
+	"\n" +
+	"ExitReason\x12\x1b\n" +
+	"\x17EXIT_REASON_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12EXIT_REASON_EXITED\x10\x01\x12\x18\n" +
+	"\x14EXIT_REASON_SIGNALED\x10\x02\x12\x1a\n" +
+	"\x16EXIT_REASON_OOM_KILLED\x10\x03*a\n" +
+	"\fOutputStream\x12\x1d\n" +
+	"\x19OUTPUT_STREAM_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14OUTPUT_STREAM_STDOUT\x10\x01\x12\x18\n" +
+	"\x14OUTPUT_STREAM_STDERR\x10\x02*M\n" +
+	"\aEnvBase\x12\x18\n" +
+	"\x14ENV_BASE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10ENV_BASE_MINIMAL\x10\x01\x12\x12\n" +
+	"\x0eENV_BASE_EMPTY\x10\x022\xc2\x06\n" +
 	"\rTaskerService\x12=\n" +
 	"\bStartJob\x12\x17.tasker.StartJobRequest\x1a\x18.tasker.StartJobResponse\x12:\n" +
 	"\aStopJob\x12\x16.tasker.StopJobRequest\x1a\x17.tasker.StopJobResponse\x127\n" +
-	"\x06GetJob\x12\x15.tasker.GetJobRequest\x1a\x16.tasker.GetJobResponse\x12B\n" +
-	"\tAttachJob\x12\x18.tasker.AttachJobRequest\x1a\x19.tasker.AttachJobResponse0\x01B.Z,github.com/wolves-fc/tasker/gen/proto/taskerb\x06proto3"
+	"\x06GetJob\x12\x15.tasker.GetJobRequest\x1a\x16.tasker.GetJobResponse\x12=\n" +
+	"\bListJobs\x12\x17.tasker.ListJobsRequest\x1a\x18.tasker.ListJobsResponse\x12B\n" +
+	"\tAttachJob\x12\x18.tasker.AttachJobRequest\x1a\x19.tasker.AttachJobResponse0\x01\x12K\n" +
+	"\fSendJobInput\x12\x1b.tasker.SendJobInputRequest\x1a\x1c.tasker.SendJobInputResponse(\x01\x12@\n" +
+	"\tSignalJob\x12\x18.tasker.SignalJobRequest\x1a\x19.tasker.SignalJobResponse\x12=\n" +
+	"\bPauseJob\x12\x17.tasker.PauseJobRequest\x1a\x18.tasker.PauseJobResponse\x12@\n" +
+	"\tResumeJob\x12\x18.tasker.ResumeJobRequest\x1a\x19.tasker.ResumeJobResponse\x12R\n" +
+	"\x0fUpdateJobLimits\x12\x1e.tasker.UpdateJobLimitsRequest\x1a\x1f.tasker.UpdateJobLimitsResponse\x12F\n" +
+	"\vGetJobStats\x12\x1a.tasker.GetJobStatsRequest\x1a\x1b.tasker.GetJobStatsResponse\x12N\n" +
+	"\rWatchJobStats\x12\x1c.tasker.WatchJobStatsRequest\x1a\x1d.tasker.WatchJobStatsResponse0\x01B.Z,github.com/wolves-fc/tasker/gen/proto/taskerb\x06proto3"
 
 var (
 	file_tasker_tasker_proto_rawDescOnce sync.Once
@@ -743,43 +3158,121 @@ func file_tasker_tasker_proto_rawDescGZIP() []byte {
 	return file_tasker_tasker_proto_rawDescData
 }
 
-var file_tasker_tasker_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_tasker_tasker_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_tasker_tasker_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_tasker_tasker_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_tasker_tasker_proto_goTypes = []any{
-	(JobPhase)(0),             // 0: tasker.JobPhase
-	(*ResourceLimits)(nil),    // 1: tasker.ResourceLimits
-	(*IOLimits)(nil),          // 2: tasker.IOLimits
-	(*Job)(nil),               // 3: tasker.Job
-	(*StartJobRequest)(nil),   // 4: tasker.StartJobRequest
-	(*StartJobResponse)(nil),  // 5: tasker.StartJobResponse
-	(*StopJobRequest)(nil),    // 6: tasker.StopJobRequest
-	(*StopJobResponse)(nil),   // 7: tasker.StopJobResponse
-	(*GetJobRequest)(nil),     // 8: tasker.GetJobRequest
-	(*GetJobResponse)(nil),    // 9: tasker.GetJobResponse
-	(*AttachJobRequest)(nil),  // 10: tasker.AttachJobRequest
-	(*AttachJobResponse)(nil), // 11: tasker.AttachJobResponse
+	(JobPhase)(0),                   // 0: tasker.JobPhase
+	(ExitReason)(0),                 // 1: tasker.ExitReason
+	(OutputStream)(0),               // 2: tasker.OutputStream
+	(EnvBase)(0),                    // 3: tasker.EnvBase
+	(*ResourceLimits)(nil),          // 4: tasker.ResourceLimits
+	(*IOLimits)(nil),                // 5: tasker.IOLimits
+	(*ExitStatus)(nil),              // 6: tasker.ExitStatus
+	(*PhaseTransition)(nil),         // 7: tasker.PhaseTransition
+	(*Job)(nil),                     // 8: tasker.Job
+	(*JobStats)(nil),                // 9: tasker.JobStats
+	(*CPUStats)(nil),                // 10: tasker.CPUStats
+	(*MemoryStats)(nil),             // 11: tasker.MemoryStats
+	(*IOStats)(nil),                 // 12: tasker.IOStats
+	(*PIDsStats)(nil),               // 13: tasker.PIDsStats
+	(*StartJobRequest)(nil),         // 14: tasker.StartJobRequest
+	(*StartJobResponse)(nil),        // 15: tasker.StartJobResponse
+	(*StopJobRequest)(nil),          // 16: tasker.StopJobRequest
+	(*StopJobResponse)(nil),         // 17: tasker.StopJobResponse
+	(*GetJobRequest)(nil),           // 18: tasker.GetJobRequest
+	(*GetJobResponse)(nil),          // 19: tasker.GetJobResponse
+	(*ListJobsRequest)(nil),         // 20: tasker.ListJobsRequest
+	(*ListJobsResponse)(nil),        // 21: tasker.ListJobsResponse
+	(*AttachJobRequest)(nil),        // 22: tasker.AttachJobRequest
+	(*AttachJobResponse)(nil),       // 23: tasker.AttachJobResponse
+	(*SendJobInputRequest)(nil),     // 24: tasker.SendJobInputRequest
+	(*WindowSize)(nil),              // 25: tasker.WindowSize
+	(*SendJobInputResponse)(nil),    // 26: tasker.SendJobInputResponse
+	(*SignalJobRequest)(nil),        // 27: tasker.SignalJobRequest
+	(*SignalJobResponse)(nil),       // 28: tasker.SignalJobResponse
+	(*PauseJobRequest)(nil),         // 29: tasker.PauseJobRequest
+	(*PauseJobResponse)(nil),        // 30: tasker.PauseJobResponse
+	(*ResumeJobRequest)(nil),        // 31: tasker.ResumeJobRequest
+	(*ResumeJobResponse)(nil),       // 32: tasker.ResumeJobResponse
+	(*UpdateJobLimitsRequest)(nil),  // 33: tasker.UpdateJobLimitsRequest
+	(*UpdateJobLimitsResponse)(nil), // 34: tasker.UpdateJobLimitsResponse
+	(*GetJobStatsRequest)(nil),      // 35: tasker.GetJobStatsRequest
+	(*GetJobStatsResponse)(nil),     // 36: tasker.GetJobStatsResponse
+	(*WatchJobStatsRequest)(nil),    // 37: tasker.WatchJobStatsRequest
+	(*WatchJobStatsResponse)(nil),   // 38: tasker.WatchJobStatsResponse
+	nil,                             // 39: tasker.StartJobRequest.EnvEntry
+	(*timestamppb.Timestamp)(nil),   // 40: google.protobuf.Timestamp
 }
 var file_tasker_tasker_proto_depIdxs = []int32{
-	2,  // 0: tasker.ResourceLimits.io:type_name -> tasker.IOLimits
-	0,  // 1: tasker.Job.phase:type_name -> tasker.JobPhase
-	1,  // 2: tasker.Job.limits:type_name -> tasker.ResourceLimits
-	1,  // 3: tasker.StartJobRequest.limits:type_name -> tasker.ResourceLimits
-	3,  // 4: tasker.StartJobResponse.job:type_name -> tasker.Job
-	3,  // 5: tasker.StopJobResponse.job:type_name -> tasker.Job
-	3,  // 6: tasker.GetJobResponse.job:type_name -> tasker.Job
-	4,  // 7: tasker.TaskerService.StartJob:input_type -> tasker.StartJobRequest
-	6,  // 8: tasker.TaskerService.StopJob:input_type -> tasker.StopJobRequest
-	8,  // 9: tasker.TaskerService.GetJob:input_type -> tasker.GetJobRequest
-	10, // 10: tasker.TaskerService.AttachJob:input_type -> tasker.AttachJobRequest
-	5,  // 11: tasker.TaskerService.StartJob:output_type -> tasker.StartJobResponse
-	7,  // 12: tasker.TaskerService.StopJob:output_type -> tasker.StopJobResponse
-	9,  // 13: tasker.TaskerService.GetJob:output_type -> tasker.GetJobResponse
-	11, // 14: tasker.TaskerService.AttachJob:output_type -> tasker.AttachJobResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	5,  // 0: tasker.ResourceLimits.io:type_name -> tasker.IOLimits
+	1,  // 1: tasker.ExitStatus.reason:type_name -> tasker.ExitReason
+	0,  // 2: tasker.PhaseTransition.from:type_name -> tasker.JobPhase
+	0,  // 3: tasker.PhaseTransition.to:type_name -> tasker.JobPhase
+	40, // 4: tasker.PhaseTransition.time:type_name -> google.protobuf.Timestamp
+	0,  // 5: tasker.Job.phase:type_name -> tasker.JobPhase
+	4,  // 6: tasker.Job.limits:type_name -> tasker.ResourceLimits
+	6,  // 7: tasker.Job.exit:type_name -> tasker.ExitStatus
+	40, // 8: tasker.Job.created_at:type_name -> google.protobuf.Timestamp
+	40, // 9: tasker.Job.started_at:type_name -> google.protobuf.Timestamp
+	40, // 10: tasker.Job.finished_at:type_name -> google.protobuf.Timestamp
+	7,  // 11: tasker.Job.transitions:type_name -> tasker.PhaseTransition
+	9,  // 12: tasker.Job.usage:type_name -> tasker.JobStats
+	40, // 13: tasker.JobStats.time:type_name -> google.protobuf.Timestamp
+	10, // 14: tasker.JobStats.cpu:type_name -> tasker.CPUStats
+	11, // 15: tasker.JobStats.memory:type_name -> tasker.MemoryStats
+	12, // 16: tasker.JobStats.io:type_name -> tasker.IOStats
+	13, // 17: tasker.JobStats.pids:type_name -> tasker.PIDsStats
+	4,  // 18: tasker.StartJobRequest.limits:type_name -> tasker.ResourceLimits
+	39, // 19: tasker.StartJobRequest.env:type_name -> tasker.StartJobRequest.EnvEntry
+	3,  // 20: tasker.StartJobRequest.env_base:type_name -> tasker.EnvBase
+	8,  // 21: tasker.StartJobResponse.job:type_name -> tasker.Job
+	8,  // 22: tasker.StopJobResponse.job:type_name -> tasker.Job
+	8,  // 23: tasker.GetJobResponse.job:type_name -> tasker.Job
+	0,  // 24: tasker.ListJobsRequest.phase:type_name -> tasker.JobPhase
+	40, // 25: tasker.ListJobsRequest.created_after:type_name -> google.protobuf.Timestamp
+	40, // 26: tasker.ListJobsRequest.created_before:type_name -> google.protobuf.Timestamp
+	8,  // 27: tasker.ListJobsResponse.jobs:type_name -> tasker.Job
+	2,  // 28: tasker.AttachJobRequest.stream:type_name -> tasker.OutputStream
+	40, // 29: tasker.AttachJobRequest.since:type_name -> google.protobuf.Timestamp
+	2,  // 30: tasker.AttachJobResponse.stream:type_name -> tasker.OutputStream
+	40, // 31: tasker.AttachJobResponse.time:type_name -> google.protobuf.Timestamp
+	25, // 32: tasker.SendJobInputRequest.resize:type_name -> tasker.WindowSize
+	8,  // 33: tasker.SignalJobResponse.job:type_name -> tasker.Job
+	8,  // 34: tasker.PauseJobResponse.job:type_name -> tasker.Job
+	8,  // 35: tasker.ResumeJobResponse.job:type_name -> tasker.Job
+	4,  // 36: tasker.UpdateJobLimitsRequest.limits:type_name -> tasker.ResourceLimits
+	8,  // 37: tasker.UpdateJobLimitsResponse.job:type_name -> tasker.Job
+	9,  // 38: tasker.GetJobStatsResponse.stats:type_name -> tasker.JobStats
+	9,  // 39: tasker.WatchJobStatsResponse.stats:type_name -> tasker.JobStats
+	14, // 40: tasker.TaskerService.StartJob:input_type -> tasker.StartJobRequest
+	16, // 41: tasker.TaskerService.StopJob:input_type -> tasker.StopJobRequest
+	18, // 42: tasker.TaskerService.GetJob:input_type -> tasker.GetJobRequest
+	20, // 43: tasker.TaskerService.ListJobs:input_type -> tasker.ListJobsRequest
+	22, // 44: tasker.TaskerService.AttachJob:input_type -> tasker.AttachJobRequest
+	24, // 45: tasker.TaskerService.SendJobInput:input_type -> tasker.SendJobInputRequest
+	27, // 46: tasker.TaskerService.SignalJob:input_type -> tasker.SignalJobRequest
+	29, // 47: tasker.TaskerService.PauseJob:input_type -> tasker.PauseJobRequest
+	31, // 48: tasker.TaskerService.ResumeJob:input_type -> tasker.ResumeJobRequest
+	33, // 49: tasker.TaskerService.UpdateJobLimits:input_type -> tasker.UpdateJobLimitsRequest
+	35, // 50: tasker.TaskerService.GetJobStats:input_type -> tasker.GetJobStatsRequest
+	37, // 51: tasker.TaskerService.WatchJobStats:input_type -> tasker.WatchJobStatsRequest
+	15, // 52: tasker.TaskerService.StartJob:output_type -> tasker.StartJobResponse
+	17, // 53: tasker.TaskerService.StopJob:output_type -> tasker.StopJobResponse
+	19, // 54: tasker.TaskerService.GetJob:output_type -> tasker.GetJobResponse
+	21, // 55: tasker.TaskerService.ListJobs:output_type -> tasker.ListJobsResponse
+	23, // 56: tasker.TaskerService.AttachJob:output_type -> tasker.AttachJobResponse
+	26, // 57: tasker.TaskerService.SendJobInput:output_type -> tasker.SendJobInputResponse
+	28, // 58: tasker.TaskerService.SignalJob:output_type -> tasker.SignalJobResponse
+	30, // 59: tasker.TaskerService.PauseJob:output_type -> tasker.PauseJobResponse
+	32, // 60: tasker.TaskerService.ResumeJob:output_type -> tasker.ResumeJobResponse
+	34, // 61: tasker.TaskerService.UpdateJobLimits:output_type -> tasker.UpdateJobLimitsResponse
+	36, // 62: tasker.TaskerService.GetJobStats:output_type -> tasker.GetJobStatsResponse
+	38, // 63: tasker.TaskerService.WatchJobStats:output_type -> tasker.WatchJobStatsResponse
+	52, // [52:64] is the sub-list for method output_type
+	40, // [40:52] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_tasker_tasker_proto_init() }
@@ -789,13 +3282,22 @@ func file_tasker_tasker_proto_init() {
 	}
 	file_tasker_tasker_proto_msgTypes[0].OneofWrappers = []any{}
 	file_tasker_tasker_proto_msgTypes[1].OneofWrappers = []any{}
+	file_tasker_tasker_proto_msgTypes[10].OneofWrappers = []any{}
+	file_tasker_tasker_proto_msgTypes[12].OneofWrappers = []any{}
+	file_tasker_tasker_proto_msgTypes[16].OneofWrappers = []any{}
+	file_tasker_tasker_proto_msgTypes[20].OneofWrappers = []any{
+		(*SendJobInputRequest_Data)(nil),
+		(*SendJobInputRequest_Close)(nil),
+		(*SendJobInputRequest_Resize)(nil),
+	}
+	file_tasker_tasker_proto_msgTypes[33].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tasker_tasker_proto_rawDesc), len(file_tasker_tasker_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   11,
+			NumEnums:      4,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
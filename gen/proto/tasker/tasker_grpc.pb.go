@@ -19,10 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TaskerService_StartJob_FullMethodName  = "/tasker.TaskerService/StartJob"
-	TaskerService_StopJob_FullMethodName   = "/tasker.TaskerService/StopJob"
-	TaskerService_GetJob_FullMethodName    = "/tasker.TaskerService/GetJob"
-	TaskerService_AttachJob_FullMethodName = "/tasker.TaskerService/AttachJob"
+	TaskerService_StartJob_FullMethodName        = "/tasker.TaskerService/StartJob"
+	TaskerService_StopJob_FullMethodName         = "/tasker.TaskerService/StopJob"
+	TaskerService_GetJob_FullMethodName          = "/tasker.TaskerService/GetJob"
+	TaskerService_ListJobs_FullMethodName        = "/tasker.TaskerService/ListJobs"
+	TaskerService_AttachJob_FullMethodName       = "/tasker.TaskerService/AttachJob"
+	TaskerService_SendJobInput_FullMethodName    = "/tasker.TaskerService/SendJobInput"
+	TaskerService_SignalJob_FullMethodName       = "/tasker.TaskerService/SignalJob"
+	TaskerService_PauseJob_FullMethodName        = "/tasker.TaskerService/PauseJob"
+	TaskerService_ResumeJob_FullMethodName       = "/tasker.TaskerService/ResumeJob"
+	TaskerService_UpdateJobLimits_FullMethodName = "/tasker.TaskerService/UpdateJobLimits"
+	TaskerService_GetJobStats_FullMethodName     = "/tasker.TaskerService/GetJobStats"
+	TaskerService_WatchJobStats_FullMethodName   = "/tasker.TaskerService/WatchJobStats"
 )
 
 // TaskerServiceClient is the client API for TaskerService service.
@@ -37,8 +45,24 @@ type TaskerServiceClient interface {
 	StopJob(ctx context.Context, in *StopJobRequest, opts ...grpc.CallOption) (*StopJobResponse, error)
 	// GetJob returns a job.
 	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
+	// ListJobs returns a page of jobs matching the filters.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
 	// AttachJob opens a stream for job output (stdout/stderr).
 	AttachJob(ctx context.Context, in *AttachJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttachJobResponse], error)
+	// SendJobInput opens a stream that writes to a job's stdin.
+	SendJobInput(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SendJobInputRequest, SendJobInputResponse], error)
+	// SignalJob sends a signal to a running job without stopping it.
+	SignalJob(ctx context.Context, in *SignalJobRequest, opts ...grpc.CallOption) (*SignalJobResponse, error)
+	// PauseJob freezes every process of a running job.
+	PauseJob(ctx context.Context, in *PauseJobRequest, opts ...grpc.CallOption) (*PauseJobResponse, error)
+	// ResumeJob thaws a paused job.
+	ResumeJob(ctx context.Context, in *ResumeJobRequest, opts ...grpc.CallOption) (*ResumeJobResponse, error)
+	// UpdateJobLimits changes the resource limits of a running job.
+	UpdateJobLimits(ctx context.Context, in *UpdateJobLimitsRequest, opts ...grpc.CallOption) (*UpdateJobLimitsResponse, error)
+	// GetJobStats returns a sample of a job's resource usage.
+	GetJobStats(ctx context.Context, in *GetJobStatsRequest, opts ...grpc.CallOption) (*GetJobStatsResponse, error)
+	// WatchJobStats opens a stream of a job's resource usage sampled at an interval.
+	WatchJobStats(ctx context.Context, in *WatchJobStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchJobStatsResponse], error)
 }
 
 type taskerServiceClient struct {
@@ -79,6 +103,16 @@ func (c *taskerServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opt
 	return out, nil
 }
 
+func (c *taskerServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, TaskerService_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskerServiceClient) AttachJob(ctx context.Context, in *AttachJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttachJobResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &TaskerService_ServiceDesc.Streams[0], TaskerService_AttachJob_FullMethodName, cOpts...)
@@ -98,6 +132,88 @@ func (c *taskerServiceClient) AttachJob(ctx context.Context, in *AttachJobReques
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TaskerService_AttachJobClient = grpc.ServerStreamingClient[AttachJobResponse]
 
+func (c *taskerServiceClient) SendJobInput(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SendJobInputRequest, SendJobInputResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskerService_ServiceDesc.Streams[1], TaskerService_SendJobInput_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SendJobInputRequest, SendJobInputResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskerService_SendJobInputClient = grpc.ClientStreamingClient[SendJobInputRequest, SendJobInputResponse]
+
+func (c *taskerServiceClient) SignalJob(ctx context.Context, in *SignalJobRequest, opts ...grpc.CallOption) (*SignalJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignalJobResponse)
+	err := c.cc.Invoke(ctx, TaskerService_SignalJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskerServiceClient) PauseJob(ctx context.Context, in *PauseJobRequest, opts ...grpc.CallOption) (*PauseJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseJobResponse)
+	err := c.cc.Invoke(ctx, TaskerService_PauseJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskerServiceClient) ResumeJob(ctx context.Context, in *ResumeJobRequest, opts ...grpc.CallOption) (*ResumeJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeJobResponse)
+	err := c.cc.Invoke(ctx, TaskerService_ResumeJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskerServiceClient) UpdateJobLimits(ctx context.Context, in *UpdateJobLimitsRequest, opts ...grpc.CallOption) (*UpdateJobLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateJobLimitsResponse)
+	err := c.cc.Invoke(ctx, TaskerService_UpdateJobLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskerServiceClient) GetJobStats(ctx context.Context, in *GetJobStatsRequest, opts ...grpc.CallOption) (*GetJobStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJobStatsResponse)
+	err := c.cc.Invoke(ctx, TaskerService_GetJobStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskerServiceClient) WatchJobStats(ctx context.Context, in *WatchJobStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchJobStatsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskerService_ServiceDesc.Streams[2], TaskerService_WatchJobStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchJobStatsRequest, WatchJobStatsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskerService_WatchJobStatsClient = grpc.ServerStreamingClient[WatchJobStatsResponse]
+
 // TaskerServiceServer is the server API for TaskerService service.
 // All implementations must embed UnimplementedTaskerServiceServer
 // for forward compatibility.
@@ -110,8 +226,24 @@ type TaskerServiceServer interface {
 	StopJob(context.Context, *StopJobRequest) (*StopJobResponse, error)
 	// GetJob returns a job.
 	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
+	// ListJobs returns a page of jobs matching the filters.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
 	// AttachJob opens a stream for job output (stdout/stderr).
 	AttachJob(*AttachJobRequest, grpc.ServerStreamingServer[AttachJobResponse]) error
+	// SendJobInput opens a stream that writes to a job's stdin.
+	SendJobInput(grpc.ClientStreamingServer[SendJobInputRequest, SendJobInputResponse]) error
+	// SignalJob sends a signal to a running job without stopping it.
+	SignalJob(context.Context, *SignalJobRequest) (*SignalJobResponse, error)
+	// PauseJob freezes every process of a running job.
+	PauseJob(context.Context, *PauseJobRequest) (*PauseJobResponse, error)
+	// ResumeJob thaws a paused job.
+	ResumeJob(context.Context, *ResumeJobRequest) (*ResumeJobResponse, error)
+	// UpdateJobLimits changes the resource limits of a running job.
+	UpdateJobLimits(context.Context, *UpdateJobLimitsRequest) (*UpdateJobLimitsResponse, error)
+	// GetJobStats returns a sample of a job's resource usage.
+	GetJobStats(context.Context, *GetJobStatsRequest) (*GetJobStatsResponse, error)
+	// WatchJobStats opens a stream of a job's resource usage sampled at an interval.
+	WatchJobStats(*WatchJobStatsRequest, grpc.ServerStreamingServer[WatchJobStatsResponse]) error
 	mustEmbedUnimplementedTaskerServiceServer()
 }
 
@@ -131,9 +263,33 @@ func (UnimplementedTaskerServiceServer) StopJob(context.Context, *StopJobRequest
 func (UnimplementedTaskerServiceServer) GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetJob not implemented")
 }
+func (UnimplementedTaskerServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListJobs not implemented")
+}
 func (UnimplementedTaskerServiceServer) AttachJob(*AttachJobRequest, grpc.ServerStreamingServer[AttachJobResponse]) error {
 	return status.Error(codes.Unimplemented, "method AttachJob not implemented")
 }
+func (UnimplementedTaskerServiceServer) SendJobInput(grpc.ClientStreamingServer[SendJobInputRequest, SendJobInputResponse]) error {
+	return status.Error(codes.Unimplemented, "method SendJobInput not implemented")
+}
+func (UnimplementedTaskerServiceServer) SignalJob(context.Context, *SignalJobRequest) (*SignalJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SignalJob not implemented")
+}
+func (UnimplementedTaskerServiceServer) PauseJob(context.Context, *PauseJobRequest) (*PauseJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseJob not implemented")
+}
+func (UnimplementedTaskerServiceServer) ResumeJob(context.Context, *ResumeJobRequest) (*ResumeJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeJob not implemented")
+}
+func (UnimplementedTaskerServiceServer) UpdateJobLimits(context.Context, *UpdateJobLimitsRequest) (*UpdateJobLimitsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateJobLimits not implemented")
+}
+func (UnimplementedTaskerServiceServer) GetJobStats(context.Context, *GetJobStatsRequest) (*GetJobStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetJobStats not implemented")
+}
+func (UnimplementedTaskerServiceServer) WatchJobStats(*WatchJobStatsRequest, grpc.ServerStreamingServer[WatchJobStatsResponse]) error {
+	return status.Error(codes.Unimplemented, "method WatchJobStats not implemented")
+}
 func (UnimplementedTaskerServiceServer) mustEmbedUnimplementedTaskerServiceServer() {}
 func (UnimplementedTaskerServiceServer) testEmbeddedByValue()                       {}
 
@@ -209,6 +365,24 @@ func _TaskerService_GetJob_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskerService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskerServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskerService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskerServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskerService_AttachJob_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(AttachJobRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -220,6 +394,114 @@ func _TaskerService_AttachJob_Handler(srv interface{}, stream grpc.ServerStream)
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type TaskerService_AttachJobServer = grpc.ServerStreamingServer[AttachJobResponse]
 
+func _TaskerService_SendJobInput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskerServiceServer).SendJobInput(&grpc.GenericServerStream[SendJobInputRequest, SendJobInputResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskerService_SendJobInputServer = grpc.ClientStreamingServer[SendJobInputRequest, SendJobInputResponse]
+
+func _TaskerService_SignalJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskerServiceServer).SignalJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskerService_SignalJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskerServiceServer).SignalJob(ctx, req.(*SignalJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskerService_PauseJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskerServiceServer).PauseJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskerService_PauseJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskerServiceServer).PauseJob(ctx, req.(*PauseJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskerService_ResumeJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskerServiceServer).ResumeJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskerService_ResumeJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskerServiceServer).ResumeJob(ctx, req.(*ResumeJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskerService_UpdateJobLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateJobLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskerServiceServer).UpdateJobLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskerService_UpdateJobLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskerServiceServer).UpdateJobLimits(ctx, req.(*UpdateJobLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskerService_GetJobStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskerServiceServer).GetJobStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskerService_GetJobStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskerServiceServer).GetJobStats(ctx, req.(*GetJobStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskerService_WatchJobStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJobStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskerServiceServer).WatchJobStats(m, &grpc.GenericServerStream[WatchJobStatsRequest, WatchJobStatsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskerService_WatchJobStatsServer = grpc.ServerStreamingServer[WatchJobStatsResponse]
+
 // TaskerService_ServiceDesc is the grpc.ServiceDesc for TaskerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,6 +521,30 @@ var TaskerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetJob",
 			Handler:    _TaskerService_GetJob_Handler,
 		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _TaskerService_ListJobs_Handler,
+		},
+		{
+			MethodName: "SignalJob",
+			Handler:    _TaskerService_SignalJob_Handler,
+		},
+		{
+			MethodName: "PauseJob",
+			Handler:    _TaskerService_PauseJob_Handler,
+		},
+		{
+			MethodName: "ResumeJob",
+			Handler:    _TaskerService_ResumeJob_Handler,
+		},
+		{
+			MethodName: "UpdateJobLimits",
+			Handler:    _TaskerService_UpdateJobLimits_Handler,
+		},
+		{
+			MethodName: "GetJobStats",
+			Handler:    _TaskerService_GetJobStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -246,6 +552,16 @@ var TaskerService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TaskerService_AttachJob_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SendJobInput",
+			Handler:       _TaskerService_SendJobInput_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchJobStats",
+			Handler:       _TaskerService_WatchJobStats_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "tasker/tasker.proto",
 }
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
+)
+
+// inputChunkSize is the max bytes sent per SendJobInput message.
+const inputChunkSize = 32 * 1024
+
+// Compile time verification that JobInput implements io.WriteCloser.
+var _ io.WriteCloser = (*JobInput)(nil)
+
+// JobInput writes to a job's stdin over a SendJobInput stream.
+//
+// It is safe to Write and Resize from different goroutines.
+type JobInput struct {
+	mu     sync.Mutex
+	stream grpc.ClientStreamingClient[taskerpb.SendJobInputRequest, taskerpb.SendJobInputResponse]
+}
+
+// SendJobInput opens a stream to the job's stdin.
+//
+// Call Close to send EOF to the job and end the stream.
+func (c *Client) SendJobInput(ctx context.Context, id string) (*JobInput, error) {
+	if id == "" {
+		return nil, fmt.Errorf("job id is required")
+	}
+
+	stream, err := c.conn.Tasker.SendJobInput(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first message identifies the job
+	in := &JobInput{stream: stream}
+	if err := in.send(&taskerpb.SendJobInputRequest{Id: id}); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// Write sends data to the job's stdin.
+func (in *JobInput) Write(data []byte) (int, error) {
+	written := 0
+	for written < len(data) {
+		end := min(written+inputChunkSize, len(data))
+		if err := in.send(&taskerpb.SendJobInputRequest{
+			Input: &taskerpb.SendJobInputRequest_Data{Data: data[written:end]},
+		}); err != nil {
+			return written, err
+		}
+
+		written = end
+	}
+
+	return written, nil
+}
+
+// Resize sets the window size of the job's terminal.
+func (in *JobInput) Resize(rows, cols uint32) error {
+	return in.send(&taskerpb.SendJobInputRequest{
+		Input: &taskerpb.SendJobInputRequest_Resize{Resize: &taskerpb.WindowSize{Rows: rows, Cols: cols}},
+	})
+}
+
+// Close closes the job's stdin and ends the stream.
+func (in *JobInput) Close() error {
+	if err := in.send(&taskerpb.SendJobInputRequest{
+		Input: &taskerpb.SendJobInputRequest_Close{Close: true},
+	}); err != nil {
+		return err
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	_, err := in.stream.CloseAndRecv()
+	return err
+}
+
+// send sends a message and returns the stream's status if the server ended the stream.
+func (in *JobInput) send(req *taskerpb.SendJobInputRequest) error {
+	// gRPC streams do not allow concurrent sends
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	err := in.stream.Send(req)
+	if err == io.EOF {
+		// The real error is only available from the server's status
+		_, err = in.stream.CloseAndRecv()
+	}
+
+	return err
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
+)
+
+const (
+	// attachBackoff is the wait before the first reconnect, doubling for each one after it up to attachMaxBackoff.
+	attachBackoff    = 100 * time.Millisecond
+	attachMaxBackoff = 5 * time.Second
+	// attachRetries is the number of reconnects tried in a row before giving up.
+	attachRetries = 10
+)
+
+// Compile time verification that JobOutput implements io.Reader and io.WriterTo.
+var (
+	_ io.Reader   = (*JobOutput)(nil)
+	_ io.WriterTo = (*JobOutput)(nil)
+)
+
+// attachFunc opens an AttachJob stream.
+type attachFunc func(
+	ctx context.Context,
+	req *taskerpb.AttachJobRequest,
+) (grpc.ServerStreamingClient[taskerpb.AttachJobResponse], error)
+
+// JobOutput reads a job's output over an AttachJob stream. When the stream breaks (e.g. the server restarts or the
+// network drops) it reconnects with backoff and resumes from the byte after the last one received.
+//
+// Reconnects always resume from an offset, so a request that started from a time or the tail isn't applied again. With
+// no follow, a reconnected stream stops at the end of the output written when it reconnected.
+type JobOutput struct {
+	ctx    context.Context
+	attach attachFunc
+	req    *taskerpb.AttachJobRequest
+	stream grpc.ServerStreamingClient[taskerpb.AttachJobResponse]
+	// data is the part of the last response Read hasn't returned yet.
+	data []byte
+}
+
+// AttachJobOutput opens a stream of the job's output that reconnects when it breaks.
+func (c *Client) AttachJobOutput(ctx context.Context, req *taskerpb.AttachJobRequest) (*JobOutput, error) {
+	return attachJobOutput(ctx, c.AttachJob, req)
+}
+
+func attachJobOutput(ctx context.Context, attach attachFunc, req *taskerpb.AttachJobRequest) (*JobOutput, error) {
+	out := &JobOutput{ctx: ctx, attach: attach, req: proto.Clone(req).(*taskerpb.AttachJobRequest)}
+
+	stream, err := attach(ctx, out.req)
+	if err != nil {
+		return nil, err
+	}
+
+	out.stream = stream
+	return out, nil
+}
+
+// Recv returns the next response, reconnecting if the stream breaks. It returns io.EOF once the server ends the
+// stream.
+func (out *JobOutput) Recv() (*taskerpb.AttachJobResponse, error) {
+	for retries := 0; ; retries++ {
+		if out.stream == nil {
+			stream, err := out.attach(out.ctx, out.req)
+			if err != nil {
+				if err := out.retry(err, retries); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			out.stream = stream
+		}
+
+		resp, err := out.stream.Recv()
+		if err == nil {
+			out.resume(resp)
+			return resp, nil
+		}
+
+		out.stream = nil
+		if err := out.retry(err, retries); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retry waits out the backoff before a reconnect or returns err if it can't be retried.
+func (out *JobOutput) retry(err error, retries int) error {
+	// The server ends the stream with a status other than unavailable on purpose
+	if status.Code(err) != codes.Unavailable || retries == attachRetries {
+		return err
+	}
+
+	timer := time.NewTimer(min(attachBackoff<<retries, attachMaxBackoff))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-out.ctx.Done():
+		return out.ctx.Err()
+	}
+}
+
+// resume points the request at the byte after the response so a reconnect continues from there.
+func (out *JobOutput) resume(resp *taskerpb.AttachJobResponse) {
+	out.req.Offset = resp.Offset + uint64(len(resp.Data))
+	out.req.Since = nil
+	out.req.TailBytes = 0
+	out.req.TailLines = 0
+}
+
+// Offset returns the offset of the next byte of output, which a new request can start at to continue from here.
+// Before the first response it is the requested offset.
+func (out *JobOutput) Offset() uint64 {
+	return out.req.Offset - uint64(len(out.data))
+}
+
+// Read reads the job's output from the streams the request selected, skipping truncation notices.
+func (out *JobOutput) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for len(out.data) == 0 {
+		resp, err := out.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		out.data = resp.Data
+	}
+
+	n := copy(p, out.data)
+	out.data = out.data[n:]
+
+	return n, nil
+}
+
+// WriteTo writes the job's output to w until the server ends the stream, skipping truncation notices.
+func (out *JobOutput) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	for {
+		// Read may have left part of a response
+		if len(out.data) > 0 {
+			n, err := w.Write(out.data)
+			written += int64(n)
+			out.data = out.data[n:]
+			if err != nil {
+				return written, err
+			}
+		}
+
+		resp, err := out.Recv()
+		if err == io.EOF {
+			return written, nil
+		}
+
+		if err != nil {
+			return written, err
+		}
+
+		out.data = resp.Data
+	}
+}
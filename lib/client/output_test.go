@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
+)
+
+// fakeAttachStream returns its responses and then err.
+type fakeAttachStream struct {
+	grpc.ClientStream
+	resps []*taskerpb.AttachJobResponse
+	err   error
+}
+
+func (s *fakeAttachStream) Recv() (*taskerpb.AttachJobResponse, error) {
+	if len(s.resps) == 0 {
+		return nil, s.err
+	}
+
+	resp := s.resps[0]
+	s.resps = s.resps[1:]
+
+	return resp, nil
+}
+
+// fakeAttach returns an attachFunc that opens the streams in order and records the offset of each request.
+func fakeAttach(offsets *[]uint64, streams ...*fakeAttachStream) attachFunc {
+	return func(
+		ctx context.Context,
+		req *taskerpb.AttachJobRequest,
+	) (grpc.ServerStreamingClient[taskerpb.AttachJobResponse], error) {
+		*offsets = append(*offsets, req.Offset)
+		stream := streams[0]
+		streams = streams[1:]
+
+		return stream, nil
+	}
+}
+
+func TestJobOutput_Resume(t *testing.T) {
+	t.Parallel()
+
+	var offsets []uint64
+	attach := fakeAttach(
+		&offsets,
+		&fakeAttachStream{
+			resps: []*taskerpb.AttachJobResponse{{Data: []byte("abc"), Offset: 0}},
+			err:   status.Error(codes.Unavailable, "connection reset"),
+		},
+		&fakeAttachStream{err: status.Error(codes.Unavailable, "connection refused")},
+		&fakeAttachStream{
+			resps: []*taskerpb.AttachJobResponse{
+				{Truncated: 2, Offset: 5},
+				{Data: []byte("fg"), Offset: 5},
+			},
+			err: io.EOF,
+		},
+	)
+
+	out, err := attachJobOutput(context.Background(), attach, &taskerpb.AttachJobRequest{Id: "job", TailLines: 10})
+	if err != nil {
+		t.Fatalf("attachJobOutput (got=%v, want=nil)", err)
+	}
+
+	got, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatalf("ReadAll (got=%v, want=nil)", err)
+	}
+
+	if string(got) != "abcfg" {
+		t.Fatalf("output (got=%q, want=%q)", got, "abcfg")
+	}
+
+	if want := []uint64{0, 3, 3}; !slices.Equal(offsets, want) {
+		t.Fatalf("request offsets (got=%v, want=%v)", offsets, want)
+	}
+
+	if out.Offset() != 7 {
+		t.Fatalf("offset (got=%d, want=7)", out.Offset())
+	}
+}
+
+func TestJobOutput_NoRetry(t *testing.T) {
+	t.Parallel()
+
+	var offsets []uint64
+	attach := fakeAttach(&offsets, &fakeAttachStream{err: status.Error(codes.NotFound, "job not found")})
+
+	out, err := attachJobOutput(context.Background(), attach, &taskerpb.AttachJobRequest{Id: "job"})
+	if err != nil {
+		t.Fatalf("attachJobOutput (got=%v, want=nil)", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := out.WriteTo(&buf); status.Code(err) != codes.NotFound {
+		t.Fatalf("code (got=%v, want=%v)", status.Code(err), codes.NotFound)
+	}
+
+	if len(offsets) != 1 {
+		t.Fatalf("requests (got=%d, want=1)", len(offsets))
+	}
+}
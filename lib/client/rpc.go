@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -24,12 +25,72 @@ func (c *Client) StartJob(ctx context.Context, req *taskerpb.StartJobRequest) (*
 }
 
 // StopJob stops a running job.
-func (c *Client) StopJob(ctx context.Context, id string) (*taskerpb.Job, error) {
+func (c *Client) StopJob(ctx context.Context, req *taskerpb.StopJobRequest) (*taskerpb.Job, error) {
+	if req.Id == "" {
+		return nil, fmt.Errorf("job id is required")
+	}
+
+	resp, err := c.conn.Tasker.StopJob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// SignalJob sends a signal to a running job and returns the job with the number of processes signaled.
+func (c *Client) SignalJob(ctx context.Context, req *taskerpb.SignalJobRequest) (*taskerpb.Job, uint32, error) {
+	if req.Id == "" {
+		return nil, 0, fmt.Errorf("job id is required")
+	}
+
+	if req.Signal == "" {
+		return nil, 0, fmt.Errorf("signal is required")
+	}
+
+	resp, err := c.conn.Tasker.SignalJob(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp.Job, resp.Signaled, nil
+}
+
+// PauseJob freezes a running job.
+func (c *Client) PauseJob(ctx context.Context, id string) (*taskerpb.Job, error) {
 	if id == "" {
 		return nil, fmt.Errorf("job id is required")
 	}
 
-	resp, err := c.conn.Tasker.StopJob(ctx, &taskerpb.StopJobRequest{Id: id})
+	resp, err := c.conn.Tasker.PauseJob(ctx, &taskerpb.PauseJobRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// ResumeJob thaws a paused job.
+func (c *Client) ResumeJob(ctx context.Context, id string) (*taskerpb.Job, error) {
+	if id == "" {
+		return nil, fmt.Errorf("job id is required")
+	}
+
+	resp, err := c.conn.Tasker.ResumeJob(ctx, &taskerpb.ResumeJobRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// UpdateJobLimits changes a running job's resource limits.
+func (c *Client) UpdateJobLimits(ctx context.Context, req *taskerpb.UpdateJobLimitsRequest) (*taskerpb.Job, error) {
+	if req.Id == "" {
+		return nil, fmt.Errorf("job id is required")
+	}
+
+	resp, err := c.conn.Tasker.UpdateJobLimits(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -51,14 +112,57 @@ func (c *Client) GetJob(ctx context.Context, id string) (*taskerpb.Job, error) {
 	return resp.Job, nil
 }
 
+// ListJobs returns a page of jobs matching the request filters and the token for the next page.
+func (c *Client) ListJobs(ctx context.Context, req *taskerpb.ListJobsRequest) ([]*taskerpb.Job, string, error) {
+	resp, err := c.conn.Tasker.ListJobs(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.Jobs, resp.NextPageToken, nil
+}
+
+// GetJobStats returns a sample of a job's resource usage.
+func (c *Client) GetJobStats(ctx context.Context, id string) (*taskerpb.JobStats, error) {
+	if id == "" {
+		return nil, fmt.Errorf("job id is required")
+	}
+
+	resp, err := c.conn.Tasker.GetJobStats(ctx, &taskerpb.GetJobStatsRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Stats, nil
+}
+
+// WatchJobStats opens a stream of a job's resource usage sampled every interval (0 uses the server default).
+func (c *Client) WatchJobStats(
+	ctx context.Context,
+	id string,
+	interval time.Duration,
+) (grpc.ServerStreamingClient[taskerpb.WatchJobStatsResponse], error) {
+	if id == "" {
+		return nil, fmt.Errorf("job id is required")
+	}
+
+	req := &taskerpb.WatchJobStatsRequest{Id: id}
+	if interval > 0 {
+		ms := uint32(interval / time.Millisecond)
+		req.IntervalMs = &ms
+	}
+
+	return c.conn.Tasker.WatchJobStats(ctx, req)
+}
+
 // AttachJob opens a stream of the job's output.
 func (c *Client) AttachJob(
 	ctx context.Context,
-	id string,
+	req *taskerpb.AttachJobRequest,
 ) (grpc.ServerStreamingClient[taskerpb.AttachJobResponse], error) {
-	if id == "" {
+	if req.Id == "" {
 		return nil, fmt.Errorf("job id is required")
 	}
 
-	return c.conn.Tasker.AttachJob(ctx, &taskerpb.AttachJobRequest{Id: id})
+	return c.conn.Tasker.AttachJob(ctx, req)
 }
@@ -18,11 +18,14 @@ const (
 	cpuPeriod = 100000
 )
 
+// controllers are the cgroup controllers enabled for job cgroups.
+var controllers = []string{"cpu", "cpuset", "memory", "io", "pids"}
+
 // Init creates the tasker cgroup and enables controllers.
 func Init() error {
 	// Enable controllers at the root so they can be delegated to the tasker subtree.
 	rootSubtreeControl := "/sys/fs/cgroup/cgroup.subtree_control"
-	for _, controller := range []string{"cpu", "memory", "io"} {
+	for _, controller := range controllers {
 		if err := writeCgroup(rootSubtreeControl, "+"+controller); err != nil {
 			return fmt.Errorf("enable root controller (controller=%s): %w", controller, err)
 		}
@@ -34,7 +37,7 @@ func Init() error {
 
 	// Enable controllers in tasker subtree for job cgroups.
 	subtreeControl := filepath.Join(cgroupTaskerDir, "cgroup.subtree_control")
-	for _, controller := range []string{"cpu", "memory", "io"} {
+	for _, controller := range controllers {
 		if err := writeCgroup(subtreeControl, "+"+controller); err != nil {
 			return fmt.Errorf("enable controller (controller=%s): %w", controller, err)
 		}
@@ -57,60 +60,232 @@ func createCgroup(id string, limits Limits) (fd int, err error) {
 		}
 	}()
 
+	if err = applyLimits(id, limits); err != nil {
+		return -1, err
+	}
+
+	fd, err = unix.Open(dir, unix.O_RDONLY|unix.O_DIRECTORY, 0)
+	if err != nil {
+		return -1, fmt.Errorf("open job cgroup: %w", err)
+	}
+
+	return fd, nil
+}
+
+// applyLimits writes the set resource limits to a job's cgroup.
+//
+// Unset limits are left as they are. IO limits replace the device's whole io.max entry (unset read/write become max).
+func applyLimits(id string, limits Limits) error {
+	dir := getCgroupDir(id)
+
+	// The kernel rejects a quota under the current burst, so clear the burst before a new pair
+	if limits.CPU != nil && limits.CPUBurst != nil {
+		if err := writeCgroup(filepath.Join(dir, "cpu.max.burst"), "0"); err != nil {
+			return fmt.Errorf("reset cpu.max.burst: %w", err)
+		}
+	}
+
 	if limits.CPU != nil {
-		if err = writeCgroup(
+		if err := writeCgroup(
 			filepath.Join(dir, "cpu.max"),
 			// quota = cores * period; max = quota period
 			fmt.Sprintf("%d %d", int(*limits.CPU*cpuPeriod), cpuPeriod),
 		); err != nil {
-			return -1, fmt.Errorf("set cpu.max: %w", err)
+			return fmt.Errorf("set cpu.max: %w", err)
+		}
+	}
+
+	if limits.CPUBurst != nil {
+		if err := writeCgroup(
+			filepath.Join(dir, "cpu.max.burst"),
+			// burst = cores * period
+			strconv.Itoa(int(*limits.CPUBurst*cpuPeriod)),
+		); err != nil {
+			return fmt.Errorf("set cpu.max.burst: %w", err)
+		}
+	}
+
+	if limits.CPUWeight != nil {
+		if err := writeCgroup(
+			filepath.Join(dir, "cpu.weight"),
+			strconv.FormatUint(uint64(*limits.CPUWeight), 10),
+		); err != nil {
+			return fmt.Errorf("set cpu.weight: %w", err)
+		}
+	}
+
+	if limits.CPUs != nil {
+		if err := writeCgroup(filepath.Join(dir, "cpuset.cpus"), *limits.CPUs); err != nil {
+			return fmt.Errorf("set cpuset.cpus: %w", err)
+		}
+	}
+
+	if limits.Mems != nil {
+		if err := writeCgroup(filepath.Join(dir, "cpuset.mems"), *limits.Mems); err != nil {
+			return fmt.Errorf("set cpuset.mems: %w", err)
 		}
 	}
 
 	if limits.Memory != nil {
-		if err = writeCgroup(
+		if err := writeCgroup(
 			filepath.Join(dir, "memory.max"),
 			// max = MB -> bytes
 			strconv.FormatUint(uint64(*limits.Memory)*1024*1024, 10),
 		); err != nil {
-			return -1, fmt.Errorf("set memory.max: %w", err)
+			return fmt.Errorf("set memory.max: %w", err)
 		}
 	}
 
-	if limits.IO != nil {
-		var device string
-		device, err = lookupBlockDevice(limits.IO.Device)
-		if err != nil {
-			return -1, fmt.Errorf("lookup block device (device=%s): %w", limits.IO.Device, err)
+	if limits.MemoryHigh != nil {
+		if err := writeCgroup(
+			filepath.Join(dir, "memory.high"),
+			// high = MB -> bytes
+			strconv.FormatUint(uint64(*limits.MemoryHigh)*1024*1024, 10),
+		); err != nil {
+			return fmt.Errorf("set memory.high: %w", err)
 		}
+	}
 
-		rbps := "max"
-		if limits.IO.Read != nil {
-			// max = MB -> bytes
-			rbps = strconv.FormatUint(uint64(*limits.IO.Read)*1024*1024, 10)
+	if limits.MemoryLow != nil {
+		if err := writeCgroup(
+			filepath.Join(dir, "memory.low"),
+			// low = MB -> bytes
+			strconv.FormatUint(uint64(*limits.MemoryLow)*1024*1024, 10),
+		); err != nil {
+			return fmt.Errorf("set memory.low: %w", err)
+		}
+	}
+
+	if limits.MemoryMin != nil {
+		if err := writeCgroup(
+			filepath.Join(dir, "memory.min"),
+			// min = MB -> bytes
+			strconv.FormatUint(uint64(*limits.MemoryMin)*1024*1024, 10),
+		); err != nil {
+			return fmt.Errorf("set memory.min: %w", err)
 		}
+	}
 
-		wbps := "max"
-		if limits.IO.Write != nil {
+	if limits.Swap != nil {
+		if err := writeCgroup(
+			filepath.Join(dir, "memory.swap.max"),
 			// max = MB -> bytes
-			wbps = strconv.FormatUint(uint64(*limits.IO.Write)*1024*1024, 10)
+			strconv.FormatUint(uint64(*limits.Swap)*1024*1024, 10),
+		); err != nil {
+			return fmt.Errorf("set memory.swap.max: %w", err)
+		}
+	}
+
+	// The kernel keeps one entry per disk, so two paths to the same disk would silently overwrite each other
+	disks := make(map[string]string, len(limits.IO))
+	for _, io := range limits.IO {
+		device, err := lookupBlockDevice(io.Device)
+		if err != nil {
+			return fmt.Errorf("lookup block device (device=%s): %w", io.Device, err)
+		}
+
+		if other, ok := disks[device]; ok {
+			return fmt.Errorf("devices share a disk (device=%s, other=%s, disk=%s)", io.Device, other, device)
 		}
 
-		if err = writeCgroup(
+		disks[device] = io.Device
+
+		if err := writeCgroup(
 			filepath.Join(dir, "io.max"),
-			// max = device rbps wbps
-			fmt.Sprintf("%s rbps=%s wbps=%s", device, rbps, wbps),
+			// max = device rbps wbps riops wiops (MB -> bytes)
+			fmt.Sprintf(
+				"%s rbps=%s wbps=%s riops=%s wiops=%s",
+				device,
+				ioMax(io.Read, 1024*1024),
+				ioMax(io.Write, 1024*1024),
+				ioMax(io.ReadIOPS, 1),
+				ioMax(io.WriteIOPS, 1),
+			),
 		); err != nil {
-			return -1, fmt.Errorf("set io.max: %w", err)
+			return fmt.Errorf("set io.max (device=%s): %w", io.Device, err)
+		}
+
+		if io.Weight != nil {
+			if err := writeCgroup(
+				filepath.Join(dir, "io.weight"),
+				fmt.Sprintf("%s %d", device, *io.Weight),
+			); err != nil {
+				return fmt.Errorf("set io.weight (device=%s): %w", io.Device, err)
+			}
+		}
+
+		if io.Latency != nil {
+			if err := writeCgroup(
+				filepath.Join(dir, "io.latency"),
+				fmt.Sprintf("%s target=%d", device, *io.Latency),
+			); err != nil {
+				return fmt.Errorf("set io.latency (device=%s): %w", io.Device, err)
+			}
 		}
 	}
 
-	fd, err = unix.Open(dir, unix.O_RDONLY|unix.O_DIRECTORY, 0)
-	if err != nil {
-		return -1, fmt.Errorf("open job cgroup: %w", err)
+	if limits.PIDs != nil {
+		if err := writeCgroup(
+			filepath.Join(dir, "pids.max"),
+			strconv.FormatUint(uint64(*limits.PIDs), 10),
+		); err != nil {
+			return fmt.Errorf("set pids.max: %w", err)
+		}
 	}
 
-	return fd, nil
+	if limits.OOMGroup != nil {
+		group := "0"
+		if *limits.OOMGroup {
+			group = "1"
+		}
+
+		if err := writeCgroup(filepath.Join(dir, "memory.oom.group"), group); err != nil {
+			return fmt.Errorf("set memory.oom.group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ioMax formats an io.max limit scaled to the kernel's unit ("max" when unset).
+func ioMax(limit *uint32, scale uint64) string {
+	if limit == nil {
+		return "max"
+	}
+
+	return strconv.FormatUint(uint64(*limit)*scale, 10)
+}
+
+// resetIOLimits lifts the io.max, io.weight and io.latency settings applied for each device.
+func resetIOLimits(id string, limits []IOLimits) error {
+	dir := getCgroupDir(id)
+	for _, io := range limits {
+		device, err := lookupBlockDevice(io.Device)
+		if err != nil {
+			return fmt.Errorf("lookup block device (device=%s): %w", io.Device, err)
+		}
+
+		if err := writeCgroup(
+			filepath.Join(dir, "io.max"),
+			fmt.Sprintf("%s rbps=max wbps=max riops=max wiops=max", device),
+		); err != nil {
+			return fmt.Errorf("reset io.max (device=%s): %w", io.Device, err)
+		}
+
+		if io.Weight != nil {
+			if err := writeCgroup(filepath.Join(dir, "io.weight"), device+" default"); err != nil {
+				return fmt.Errorf("reset io.weight (device=%s): %w", io.Device, err)
+			}
+		}
+
+		if io.Latency != nil {
+			if err := writeCgroup(filepath.Join(dir, "io.latency"), device+" target=max"); err != nil {
+				return fmt.Errorf("reset io.latency (device=%s): %w", io.Device, err)
+			}
+		}
+	}
+
+	return nil
 }
 
 // writeCgroup writes data to a cgroup file.
@@ -118,6 +293,98 @@ func writeCgroup(path, data string) error {
 	return os.WriteFile(path, []byte(data), 0o644)
 }
 
+// readCgroupStats reads a flat keyed cgroup file (e.g. pids.events) of a job.
+func readCgroupStats(id, file string) (map[string]uint64, error) {
+	data, err := os.ReadFile(filepath.Join(getCgroupDir(id), file))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCgroupStats(string(data))
+}
+
+// readCgroupValue reads a single value cgroup file (e.g. memory.current) of a job.
+func readCgroupValue(id, file string) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(getCgroupDir(id), file))
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// parseCgroupStats parses the "<key> <value>" lines of a flat keyed cgroup file.
+func parseCgroupStats(data string) (map[string]uint64, error) {
+	stats := make(map[string]uint64)
+	for line := range strings.Lines(data) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+
+		num, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse cgroup stat (key=%s): %w", key, err)
+		}
+
+		stats[key] = num
+	}
+
+	return stats, nil
+}
+
+// readCgroupPIDs reads the pids of every process in a job's cgroup.
+func readCgroupPIDs(id string) ([]int, error) {
+	data, err := os.ReadFile(filepath.Join(getCgroupDir(id), "cgroup.procs"))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCgroupPIDs(string(data))
+}
+
+// parseCgroupPIDs parses the one pid per line format of cgroup.procs.
+func parseCgroupPIDs(data string) ([]int, error) {
+	var pids []int
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		pid, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, fmt.Errorf("parse cgroup pid (line=%s): %w", line, err)
+		}
+
+		pids = append(pids, pid)
+	}
+
+	return pids, nil
+}
+
+// freezeCgroup freezes or thaws every process in a job's cgroup.
+//
+// The kernel finishes freezing asynchronously so use cgroupFrozen to wait for it.
+func freezeCgroup(id string, frozen bool) error {
+	value := "0"
+	if frozen {
+		value = "1"
+	}
+
+	return writeCgroup(filepath.Join(getCgroupDir(id), "cgroup.freeze"), value)
+}
+
+// cgroupFrozen reports whether every process in a job's cgroup is frozen.
+func cgroupFrozen(id string) (bool, error) {
+	events, err := readCgroupStats(id, "cgroup.events")
+	if err != nil {
+		return false, err
+	}
+
+	return events["frozen"] == 1, nil
+}
+
 // killCgroup does a hard kill on all processes in the cgroup.
 func killCgroup(id string) error {
 	return writeCgroup(filepath.Join(getCgroupDir(id), "cgroup.kill"), "1")
@@ -4,11 +4,14 @@ package job
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/sys/unix"
 )
@@ -62,12 +65,12 @@ func findBlockDevice(t *testing.T) string {
 func TestCgroup_CPULimit(t *testing.T) {
 	cpu := float32(0.5)
 	// Sleep for 60 seconds to allow time to check the cgroup settings.
-	j, err := New("sleep", []string{"60"}, "test", Limits{CPU: &cpu})
+	j, err := New("sleep", []string{"60"}, "test", Options{Limits: Limits{CPU: &cpu}})
 	if err != nil {
 		t.Fatalf("New: %v", err)
 	}
 
-	defer j.Stop(context.Background())
+	defer j.Stop(context.Background(), 0, "test")
 
 	got := readCgroupFile(t, j.ID(), "cpu.max")
 	// quota = period * cpu; max = quota period
@@ -77,15 +80,41 @@ func TestCgroup_CPULimit(t *testing.T) {
 	}
 }
 
+func TestCgroup_CPUControls(t *testing.T) {
+	cpu, burst, weight := float32(1), float32(0.5), uint32(200)
+	cpus, mems := "0", "0"
+	// Sleep for 60 seconds to allow time to check the cgroup settings.
+	j, err := New("sleep", []string{"60"}, "test", Options{
+		Limits: Limits{CPU: &cpu, CPUBurst: &burst, CPUWeight: &weight, CPUs: &cpus, Mems: &mems},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	for file, want := range map[string]string{
+		// burst = cores * period
+		"cpu.max.burst": "50000",
+		"cpu.weight":    "200",
+		"cpuset.cpus":   "0",
+		"cpuset.mems":   "0",
+	} {
+		if got := readCgroupFile(t, j.ID(), file); got != want {
+			t.Errorf("%s (got=%q, want=%q)", file, got, want)
+		}
+	}
+}
+
 func TestCgroup_MemoryLimit(t *testing.T) {
 	memory := uint32(512)
 	// Sleep for 60 seconds to allow time to check the cgroup settings.
-	j, err := New("sleep", []string{"60"}, "test", Limits{Memory: &memory})
+	j, err := New("sleep", []string{"60"}, "test", Options{Limits: Limits{Memory: &memory}})
 	if err != nil {
 		t.Fatalf("New: %v", err)
 	}
 
-	defer j.Stop(context.Background())
+	defer j.Stop(context.Background(), 0, "test")
 
 	got := readCgroupFile(t, j.ID(), "memory.max")
 	// max = memory * 1024 * 1024
@@ -95,23 +124,52 @@ func TestCgroup_MemoryLimit(t *testing.T) {
 	}
 }
 
+func TestCgroup_MemoryControls(t *testing.T) {
+	high, low, minimum, swap := uint32(256), uint32(128), uint32(64), uint32(0)
+	// Sleep for 60 seconds to allow time to check the cgroup settings.
+	j, err := New("sleep", []string{"60"}, "test", Options{
+		Limits: Limits{MemoryHigh: &high, MemoryLow: &low, MemoryMin: &minimum, Swap: &swap},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	// value = MB * 1024 * 1024
+	for file, want := range map[string]string{
+		"memory.high":     "268435456",
+		"memory.low":      "134217728",
+		"memory.min":      "67108864",
+		"memory.swap.max": "0",
+	} {
+		if got := readCgroupFile(t, j.ID(), file); got != want {
+			t.Errorf("%s (got=%q, want=%q)", file, got, want)
+		}
+	}
+}
+
 func TestCgroup_IOLimit(t *testing.T) {
 	device := findBlockDevice(t)
 	read := uint32(100)
 	write := uint32(50)
+	readIOPS := uint32(1000)
 	// Sleep for 60 seconds to allow time to check the cgroup settings.
-	j, err := New("sleep", []string{"60"}, "test", Limits{
-		IO: &IOLimits{
-			Device: device,
-			Read:   &read,
-			Write:  &write,
+	j, err := New("sleep", []string{"60"}, "test", Options{
+		Limits: Limits{
+			IO: []IOLimits{{
+				Device:   device,
+				Read:     &read,
+				Write:    &write,
+				ReadIOPS: &readIOPS,
+			}},
 		},
 	})
 	if err != nil {
 		t.Fatalf("New: %v", err)
 	}
 
-	defer j.Stop(context.Background())
+	defer j.Stop(context.Background(), 0, "test")
 
 	deviceNum, err := lookupBlockDevice(device)
 	if err != nil {
@@ -122,8 +180,157 @@ func TestCgroup_IOLimit(t *testing.T) {
 	// rbps = read * 1024 * 1024; wbps = write * 1024 * 1024; max = deviceNum rbps wbps
 	wantRbps := fmt.Sprintf("rbps=%d", 100*1024*1024)
 	wantWbps := fmt.Sprintf("wbps=%d", 50*1024*1024)
-	if !strings.Contains(got, deviceNum) || !strings.Contains(got, wantRbps) || !strings.Contains(got, wantWbps) {
-		t.Fatalf("io.max (got=%q, want deviceNum=%s rbps=%s wbps=%s)", got, deviceNum, wantRbps, wantWbps)
+	wantRiops := "riops=1000"
+	if !strings.Contains(got, deviceNum) || !strings.Contains(got, wantRbps) || !strings.Contains(got, wantWbps) ||
+		!strings.Contains(got, wantRiops) {
+		t.Fatalf("io.max (got=%q, want deviceNum=%s %s %s %s)", got, deviceNum, wantRbps, wantWbps, wantRiops)
+	}
+
+	// Replacing the IO limits lifts the ones the update leaves out
+	if _, err := j.UpdateLimits(Limits{IO: []IOLimits{{Device: device, Write: &write}}}); err != nil {
+		t.Fatalf("UpdateLimits: %v", err)
+	}
+
+	got = readCgroupFile(t, j.ID(), "io.max")
+	if strings.Contains(got, wantRbps) || strings.Contains(got, wantRiops) || !strings.Contains(got, wantWbps) {
+		t.Fatalf("io.max after update (got=%q, want only %s)", got, wantWbps)
+	}
+}
+
+func TestCgroup_IOSameDisk(t *testing.T) {
+	device := findBlockDevice(t)
+	read := uint32(100)
+	_, err := New("sleep", []string{"60"}, "test", Options{
+		Limits: Limits{IO: []IOLimits{{Device: device, Read: &read}, {Device: device, Read: &read}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "devices share a disk") {
+		t.Fatalf("New (got=%v, want devices share a disk error)", err)
+	}
+}
+
+func TestCgroup_IOUpdateRestore(t *testing.T) {
+	device := findBlockDevice(t)
+	read := uint32(100)
+	write := uint32(50)
+	j, err := New("sleep", []string{"60"}, "test", Options{
+		Limits: Limits{IO: []IOLimits{{Device: device, Read: &read}}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	// The second entry fails after the first was written, so the old limits are put back
+	limits, err := j.UpdateLimits(Limits{IO: []IOLimits{{Device: device, Write: &write}, {Device: device, Write: &write}}})
+	if err == nil || !strings.Contains(err.Error(), "devices share a disk") {
+		t.Fatalf("UpdateLimits (got=%v, want devices share a disk error)", err)
+	}
+
+	if len(limits.IO) != 1 || limits.IO[0].Read == nil || *limits.IO[0].Read != read || limits.IO[0].Write != nil {
+		t.Fatalf("io limits (got=%+v, want read=%d only)", limits.IO, read)
+	}
+
+	got := readCgroupFile(t, j.ID(), "io.max")
+	wantRbps := fmt.Sprintf("rbps=%d", 100*1024*1024)
+	if !strings.Contains(got, wantRbps) || !strings.Contains(got, "wbps=max") {
+		t.Fatalf("io.max (got=%q, want %s wbps=max)", got, wantRbps)
+	}
+}
+
+func TestCgroup_PIDsLimit(t *testing.T) {
+	pids := uint32(5)
+	// Fork more background sleeps than allowed so some forks fail.
+	j, err := New("sh", []string{"-c", "for i in 1 2 3 4 5 6 7 8; do sleep 60 & done; echo ready; wait"}, "test", Options{
+		Limits: Limits{PIDs: &pids},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	got := readCgroupFile(t, j.ID(), "pids.max")
+	if got != "5" {
+		t.Fatalf("pids.max (got=%q, want=%q)", got, "5")
+	}
+
+	// Wait for the shell to finish forking
+	buf := make([]byte, 16)
+	j.NewReader(context.Background(), ReadOptions{}).Read(buf)
+
+	if j.PIDsMaxEvents() == 0 {
+		t.Fatal("pids max events (got=0, want>0)")
+	}
+}
+
+func TestCgroup_OOMKill(t *testing.T) {
+	memory := uint32(16)
+	oomGroup := true
+	// tail buffers /dev/zero looking for a newline until it runs out of memory
+	j, err := New("tail", []string{"/dev/zero"}, "test", Options{Limits: Limits{Memory: &memory, OOMGroup: &oomGroup}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	if got := readCgroupFile(t, j.ID(), "memory.oom.group"); got != "1" {
+		t.Fatalf("memory.oom.group (got=%q, want=%q)", got, "1")
+	}
+
+	waitPhase(t, j, PhaseCompleted, 10*time.Second)
+
+	// Wait for the process to exit
+	io.Copy(io.Discard, j.NewReader(context.Background(), ReadOptions{}))
+
+	exit := j.Exit()
+	if exit == nil || exit.Reason != ExitReasonOOMKilled {
+		t.Fatalf("exit status (got=%+v, want reason=ExitReasonOOMKilled)", exit)
+	}
+
+	if usage := j.Usage(); usage == nil || usage.Memory.OOMKill == 0 {
+		t.Fatalf("usage (got=%+v, want oom kills>0)", usage)
+	}
+}
+
+func TestCgroup_UpdateLimits(t *testing.T) {
+	memory := uint32(256)
+	j, err := New("sleep", []string{"60"}, "test", Options{Limits: Limits{Memory: &memory}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	cpu := float32(0.25)
+	newMemory := uint32(1024)
+	limits, err := j.UpdateLimits(Limits{CPU: &cpu, Memory: &newMemory})
+	if err != nil {
+		t.Fatalf("UpdateLimits: %v", err)
+	}
+
+	if got := readCgroupFile(t, j.ID(), "cpu.max"); got != "25000 100000" {
+		t.Fatalf("cpu.max (got=%q, want=%q)", got, "25000 100000")
+	}
+
+	if got := readCgroupFile(t, j.ID(), "memory.max"); got != "1073741824" {
+		t.Fatalf("memory.max (got=%q, want=%q)", got, "1073741824")
+	}
+
+	if *limits.CPU != cpu || *limits.Memory != newMemory || *j.Limits().Memory != newMemory {
+		t.Fatalf("limits (got=%+v, want cpu=%v memory=%d)", limits, cpu, newMemory)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := j.Stop(ctx, 0, "test"); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	if _, err := j.UpdateLimits(Limits{Memory: &memory}); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("UpdateLimits after Stop (got=%v, want=ErrNotRunning)", err)
 	}
 }
 
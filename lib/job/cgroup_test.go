@@ -0,0 +1,77 @@
+package job
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestParseCgroupStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := parseCgroupStats("usage_usec 1200\nuser_usec 1000\nsystem_usec 200\n")
+		if err != nil {
+			t.Fatalf("parseCgroupStats (got=%v, want=nil)", err)
+		}
+
+		want := map[string]uint64{"usage_usec": 1200, "user_usec": 1000, "system_usec": 200}
+		if !maps.Equal(got, want) {
+			t.Fatalf("stats (got=%v, want=%v)", got, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := parseCgroupStats("")
+		if err != nil || len(got) != 0 {
+			t.Fatalf("parseCgroupStats (got=(%v, %v), want=(empty, nil))", got, err)
+		}
+	})
+
+	t.Run("invalid_value", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := parseCgroupStats("max abc\n"); err == nil {
+			t.Fatal("parseCgroupStats (got=nil, want=error)")
+		}
+	})
+}
+
+func TestParseCgroupPIDs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := parseCgroupPIDs("101\n102\n\n205\n")
+		if err != nil {
+			t.Fatalf("parseCgroupPIDs (got=%v, want=nil)", err)
+		}
+
+		want := []int{101, 102, 205}
+		if !slices.Equal(got, want) {
+			t.Fatalf("pids (got=%v, want=%v)", got, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := parseCgroupPIDs("")
+		if err != nil || len(got) != 0 {
+			t.Fatalf("parseCgroupPIDs (got=(%v, %v), want=(empty, nil))", got, err)
+		}
+	})
+
+	t.Run("invalid_pid", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := parseCgroupPIDs("101\nabc\n"); err == nil {
+			t.Fatal("parseCgroupPIDs (got=nil, want=error)")
+		}
+	})
+}
@@ -3,9 +3,15 @@ package job
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/sys/unix"
@@ -15,16 +21,77 @@ import (
 type Limits struct {
 	CPU    *float32
 	Memory *uint32
-	IO     *IOLimits
+	IO     []IOLimits
+	PIDs   *uint32
+	// OOMGroup kills every process in the job together when the OOM killer picks one of them.
+	OOMGroup *bool
+	// MemoryHigh throttles and reclaims the job's memory above this many MB.
+	MemoryHigh *uint32
+	// MemoryLow and MemoryMin protect this many MB from reclaim (best effort and hard).
+	MemoryLow *uint32
+	MemoryMin *uint32
+	// Swap caps the job's swap usage in MB (0 disables swap).
+	Swap *uint32
+	// CPUWeight is the job's relative CPU share (1-10000).
+	CPUWeight *uint32
+	// CPUBurst is the extra CPU in cores the job can borrow from unused quota in earlier periods.
+	CPUBurst *float32
+	// CPUs and Mems pin the job to CPUs and NUMA memory nodes (cpuset list format e.g. 0-3,8).
+	CPUs *string
+	Mems *string
 }
 
-// IOLimits holds IO throttle limits for a block device.
+// IOLimits holds IO limits for a block device.
 type IOLimits struct {
 	Device string
-	Read   *uint32
-	Write  *uint32
+	// Read and Write cap throughput in MB/s.
+	Read  *uint32
+	Write *uint32
+	// ReadIOPS and WriteIOPS cap IO operations per second.
+	ReadIOPS  *uint32
+	WriteIOPS *uint32
+	// Weight is the job's proportional share of the device (1-10000).
+	Weight *uint32
+	// Latency is the device's completion latency target in microseconds.
+	Latency *uint32
 }
 
+// Options holds optional settings for a job.
+type Options struct {
+	Limits Limits
+	// Stdin keeps the job's stdin open for WriteInput (otherwise stdin is /dev/null).
+	Stdin bool
+	// TTY runs the job under a pseudo-terminal used for stdin, stdout and stderr.
+	TTY bool
+	// Env is the job's environment as KEY=VALUE pairs (nil inherits the server's environment).
+	Env []string
+	// Dir is the job's working directory (empty uses the server's working directory).
+	Dir string
+	// Credential is the user and groups the job runs as (nil runs as the server's user).
+	Credential *syscall.Credential
+	// Timeout is the max runtime before the job is stopped (0 never times out).
+	Timeout time.Duration
+	// StopSignal is sent to the process group by Stop and timeouts (0 uses SIGTERM).
+	StopSignal unix.Signal
+	// StopGrace is how long a stop waits after the stop signal before the cgroup is killed.
+	StopGrace time.Duration
+	// OutputLimit is the max bytes of output kept, dropping the oldest bytes over it (0 keeps everything).
+	OutputLimit int64
+	// OutputDir spills the job's output to segment files in <OutputDir>/<id> (empty keeps output in memory).
+	OutputDir string
+}
+
+var (
+	// ErrNoStdin is returned when writing input to a job that was started without stdin.
+	ErrNoStdin = errors.New("job was started without stdin")
+	// ErrNoTTY is returned when resizing a job that was started without a terminal.
+	ErrNoTTY = errors.New("job was started without a terminal")
+	// ErrNotRunning is returned when signaling, pausing or sampling a job that is not running.
+	ErrNotRunning = errors.New("job is not running")
+	// ErrNotPaused is returned when resuming a job that is not paused.
+	ErrNotPaused = errors.New("job is not paused")
+)
+
 // Phase represents the lifecycle phase of a job.
 type Phase int
 
@@ -33,63 +100,207 @@ const (
 	PhaseRunning
 	PhaseStopped
 	PhaseCompleted
+	PhaseTimedOut
+	PhasePaused
 )
 
+// freezePollInterval is how often Pause checks if the cgroup finished freezing.
+const freezePollInterval = 10 * time.Millisecond
+
+// Transition records a change in a job's phase.
+type Transition struct {
+	From Phase
+	To   Phase
+	Time time.Time
+	// By is who triggered the transition (empty if the job exited on its own).
+	By string
+}
+
+// ExitReason is why a job's process exited.
+type ExitReason int
+
+const (
+	ExitReasonUnknown ExitReason = iota
+	ExitReasonExited
+	ExitReasonSignaled
+	ExitReasonOOMKilled
+)
+
+// ExitStatus describes how a job's process exited.
+type ExitStatus struct {
+	Reason ExitReason
+	// Code is the exit code or -1 if the process was terminated by a signal.
+	Code int
+	// Signal is the signal that terminated the process (0 if it exited on its own).
+	Signal unix.Signal
+	// CoreDumped is true if the process dumped core.
+	CoreDumped bool
+}
+
 // Job represents a managed process in a cgroup.
 type Job struct {
-	done chan struct{}
+	done    chan struct{}
+	ttyDone chan struct{}
 
 	id      string
 	command string
 	args    []string
 	owner   string
-	limits  Limits
-	cmd     *exec.Cmd
-	output  *outputBuffer
+	created time.Time
+	started time.Time
+	dir     string
+	cred    *syscall.Credential
+	timeout time.Duration
+	timer   *time.Timer
+	// stopSignal and stopGrace are the job's default stop settings
+	stopSignal unix.Signal
+	stopGrace  time.Duration
+	cmd        *exec.Cmd
+	stdin      *os.File
+	tty        *os.File
+	output     *outputBuffer
 
 	mu struct {
 		sync.Mutex
-		err   error
-		phase Phase
+		err         error
+		limits      Limits
+		exit        *ExitStatus
+		phase       Phase
+		finished    time.Time
+		transitions []Transition
+		// pausing is true while Pause waits for the cgroup to freeze
+		pausing bool
+		// pidsMaxEvents is the final pids.events max count taken before the cgroup is removed
+		pidsMaxEvents uint64
+		// usage is the final resource usage taken before the cgroup is removed
+		usage *Stats
 	}
 }
 
 // New creates and starts a job in a cgroup.
 //
 // Call Stop to shut down the job.
-func New(command string, args []string, owner string, limits Limits) (*Job, error) {
+func New(command string, args []string, owner string, opts Options) (*Job, error) {
 	j := &Job{
-		done:    make(chan struct{}),
-		id:      uuid.Must(uuid.NewV7()).String(),
-		command: command,
-		args:    args,
-		owner:   owner,
-		limits:  limits,
-		output:  newOutputBuffer(),
+		done:       make(chan struct{}),
+		id:         uuid.Must(uuid.NewV7()).String(),
+		command:    command,
+		args:       args,
+		owner:      owner,
+		dir:        opts.Dir,
+		cred:       opts.Credential,
+		timeout:    opts.Timeout,
+		stopSignal: opts.StopSignal,
+		stopGrace:  opts.StopGrace,
+		created:    time.Now(),
+		output:     newOutputBuffer(opts.OutputLimit),
 	}
 
-	cgFD, err := createCgroup(j.id, j.limits)
+	if opts.OutputDir != "" {
+		output, err := newSpillOutputBuffer(filepath.Join(opts.OutputDir, j.id), opts.OutputLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		j.output = output
+	}
+
+	if j.stopSignal == 0 {
+		j.stopSignal = unix.SIGTERM
+	}
+
+	j.mu.limits = opts.Limits
+
+	cgFD, err := createCgroup(j.id, j.mu.limits)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(err, j.output.Remove())
 	}
 
 	j.cmd = exec.Command(j.command, j.args...)
-	j.cmd.Stdout = j.output
-	j.cmd.Stderr = j.output
+	j.cmd.Env = opts.Env
+	j.cmd.Dir = opts.Dir
+	j.cmd.Stdout = j.output.Stream(StreamStdout)
+	j.cmd.Stderr = j.output.Stream(StreamStderr)
 	j.cmd.SysProcAttr = &unix.SysProcAttr{
 		Setpgid:     true,
 		UseCgroupFD: true,
 		CgroupFD:    cgFD,
+		Credential:  opts.Credential,
+	}
+
+	// childStdio is the end of the stdin pipe or terminal that only the child needs
+	var childStdio *os.File
+	switch {
+	case opts.TTY:
+		j.tty, childStdio, err = openPTY()
+		if err != nil {
+			return nil, errors.Join(err, unix.Close(cgFD), removeCgroup(j.id), j.output.Remove())
+		}
+
+		// The terminal belongs to the job's user like a login terminal
+		if opts.Credential != nil {
+			if err := childStdio.Chown(int(opts.Credential.Uid), int(opts.Credential.Gid)); err != nil {
+				return nil, errors.Join(
+					fmt.Errorf("chown pty: %w", err),
+					childStdio.Close(),
+					j.tty.Close(),
+					unix.Close(cgFD),
+					removeCgroup(j.id),
+					j.output.Remove(),
+				)
+			}
+		}
+
+		// The terminal is used for all stdio and the new session makes it the controlling terminal
+		j.stdin = j.tty
+		j.ttyDone = make(chan struct{})
+		j.cmd.Stdin = childStdio
+		j.cmd.Stdout = childStdio
+		j.cmd.Stderr = childStdio
+		j.cmd.SysProcAttr.Setpgid = false
+		j.cmd.SysProcAttr.Setsid = true
+		j.cmd.SysProcAttr.Setctty = true
+	case opts.Stdin:
+		childStdio, j.stdin, err = os.Pipe()
+		if err != nil {
+			return nil, errors.Join(
+				fmt.Errorf("create stdin pipe: %w", err),
+				unix.Close(cgFD),
+				removeCgroup(j.id),
+				j.output.Remove(),
+			)
+		}
+
+		j.cmd.Stdin = childStdio
 	}
 
 	if err := j.cmd.Start(); err != nil {
-		return nil, errors.Join(err, unix.Close(cgFD), removeCgroup(j.id))
+		if childStdio != nil {
+			err = errors.Join(err, childStdio.Close(), j.stdin.Close())
+		}
+
+		return nil, errors.Join(err, unix.Close(cgFD), removeCgroup(j.id), j.output.Remove())
 	}
 
 	// fd was only needed to place the process in the cgroup
 	unix.Close(cgFD)
 
-	j.mu.phase = PhaseRunning
+	// The child has its own copy
+	if childStdio != nil {
+		childStdio.Close()
+	}
+
+	if j.tty != nil {
+		go j.copyTTY()
+	}
+
+	j.started = time.Now()
+	j.setPhase(PhaseRunning, owner)
+
+	if j.timeout > 0 {
+		j.timer = time.AfterFunc(j.timeout, j.expire)
+	}
+
 	go j.wait()
 
 	return j, nil
@@ -101,33 +312,144 @@ func (j *Job) wait() {
 
 	waitErr := j.cmd.Wait()
 
+	if j.timer != nil {
+		j.timer.Stop()
+	}
+
+	// Like the stdout/stderr pipes, terminal output is read until every process holding the terminal exits
+	if j.tty != nil {
+		<-j.ttyDone
+	}
+
 	j.mu.Lock()
+	j.mu.exit = newExitStatus(j.cmd.ProcessState)
+	j.mu.finished = time.Now()
+
 	switch j.mu.phase {
-	case PhaseRunning:
-		j.mu.phase = PhaseCompleted
-	case PhaseStopped:
+	case PhaseRunning, PhasePaused:
+		j.setPhase(PhaseCompleted, "")
+	case PhaseStopped, PhaseTimedOut:
 		// Exit error is expected when stopped
 		waitErr = nil
 	}
 
-	// Kill any stragglers, remove the cgroup, then close the output buffer
-	j.mu.err = errors.Join(waitErr, killCgroup(j.id), removeCgroup(j.id), j.output.Close())
+	// Snapshot cgroup counters before the cgroup is removed
+	if events, err := readCgroupStats(j.id, "pids.events"); err == nil {
+		j.mu.pidsMaxEvents = events["max"]
+	}
+
+	if usage, err := readStats(j.id); err == nil {
+		usage.Final = true
+		j.mu.usage = &usage
+	}
+
+	// The oom kill count is read on its own so a failed usage snapshot doesn't hide an OOM kill
+	if events, err := readCgroupStats(j.id, "memory.events"); err == nil && j.mu.exit != nil {
+		oomKills := events["oom_kill"]
+		j.mu.exit.Reason = exitReason(j.mu.phase, *j.mu.exit, oomKills)
+		if j.mu.exit.Reason == ExitReasonOOMKilled {
+			if waitErr != nil {
+				waitErr = fmt.Errorf("out of memory (oom kills=%d): %w", oomKills, waitErr)
+			} else {
+				waitErr = fmt.Errorf("out of memory (oom kills=%d)", oomKills)
+			}
+		}
+	}
+
+	// Kill any stragglers, remove the cgroup, then close stdin and the output buffer
+	j.mu.err = errors.Join(waitErr, killCgroup(j.id), removeCgroup(j.id), j.closeStdin(), j.output.Close())
 	j.mu.Unlock()
 }
 
-// Stop sends a SIGTERM and cgroup kill to the job.
-func (j *Job) Stop(ctx context.Context) error {
+// copyTTY copies the terminal's output to the output buffer.
+func (j *Job) copyTTY() {
+	defer close(j.ttyDone)
+
+	// The read fails with EIO once no process holds the terminal, which is the terminal's EOF
+	_, _ = io.Copy(j.output, j.tty)
+}
+
+// setPhase moves the job to a new phase and records the transition.
+//
+// Caller must hold j.mu unless the job has not been shared yet.
+func (j *Job) setPhase(phase Phase, by string) {
+	j.mu.transitions = append(j.mu.transitions, Transition{
+		From: j.mu.phase,
+		To:   phase,
+		Time: time.Now(),
+		By:   by,
+	})
+	j.mu.phase = phase
+}
+
+// newExitStatus builds an ExitStatus from a process state.
+func newExitStatus(state *os.ProcessState) *ExitStatus {
+	if state == nil {
+		return nil
+	}
+
+	exit := &ExitStatus{Reason: ExitReasonExited, Code: state.ExitCode()}
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		exit.Reason = ExitReasonSignaled
+		exit.Signal = ws.Signal()
+		exit.CoreDumped = ws.CoreDump()
+	}
+
+	return exit
+}
+
+// exitReason returns why a job in phase exited given the OOM kills counted in its cgroup.
+//
+// A job that completed on its own while the OOM killer killed any of its processes was OOM killed whatever its exit
+// form. The main process dies from SIGKILL when it was the one killed, but a shell whose child was killed usually exits
+// with a code instead (e.g. 137). Kills after a stop are not counted since the job was already being stopped.
+func exitReason(phase Phase, exit ExitStatus, oomKills uint64) ExitReason {
+	if phase == PhaseCompleted && oomKills > 0 {
+		return ExitReasonOOMKilled
+	}
+
+	return exit.Reason
+}
+
+// Stop sends a signal and cgroup kill to the job.
+//
+// sig is sent to the process group (0 uses the job's stop signal) and the cgroup is killed once ctx ends.
+// by is recorded as who stopped the job.
+func (j *Job) Stop(ctx context.Context, sig unix.Signal, by string) error {
+	return j.stop(ctx, PhaseStopped, sig, by)
+}
+
+// expire stops the job with its stop signal and grace once its timeout is reached.
+func (j *Job) expire() {
+	ctx, cancel := context.WithTimeout(context.Background(), j.stopGrace)
+	defer cancel()
+
+	_ = j.stop(ctx, PhaseTimedOut, 0, "timeout")
+}
+
+// stop moves a running job to phase then sends sig followed by a cgroup kill once ctx ends.
+func (j *Job) stop(ctx context.Context, phase Phase, sig unix.Signal, by string) error {
 	j.mu.Lock()
-	if j.mu.phase != PhaseRunning {
+	if !j.active() {
 		j.mu.Unlock()
 		return nil
 	}
 
-	j.mu.phase = PhaseStopped
+	paused := j.mu.phase == PhasePaused
+	j.setPhase(phase, by)
 	j.mu.Unlock()
 
-	// SIGTERM the process group
-	_ = unix.Kill(-j.cmd.Process.Pid, unix.SIGTERM)
+	if sig == 0 {
+		sig = j.stopSignal
+	}
+
+	// Signal the process group
+	_ = unix.Kill(-j.cmd.Process.Pid, sig)
+
+	// A frozen process only handles the signal once thawed
+	if paused {
+		_ = freezeCgroup(j.id, false)
+	}
 
 	select {
 	case <-j.done:
@@ -141,11 +463,392 @@ func (j *Job) Stop(ctx context.Context) error {
 	}
 }
 
-// NewReader returns a reader for the job's output from the beginning.
-func (j *Job) NewReader(ctx context.Context) io.Reader {
-	return newOutputReader(ctx, j.output)
+// Pause freezes every process in the job's cgroup and waits until they are all frozen.
+//
+// by is recorded as who paused the job. If ctx ends before the cgroup is frozen the job is thawed again. If the job is
+// stopped while freezing it is thawed so it can handle the stop signal and ErrNotRunning is returned, as it is if the
+// job exits while freezing.
+func (j *Job) Pause(ctx context.Context, by string) error {
+	j.mu.Lock()
+	if j.mu.phase != PhaseRunning || j.mu.pausing {
+		j.mu.Unlock()
+		return ErrNotRunning
+	}
+
+	if err := freezeCgroup(j.id, true); err != nil {
+		j.mu.Unlock()
+		return fmt.Errorf("freeze cgroup: %w", err)
+	}
+
+	// The lock isn't held while freezing so a slow freeze doesn't block the job's other methods
+	j.mu.pausing = true
+	j.mu.Unlock()
+
+	err := j.waitFrozen(ctx)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.finishPause(err, by)
 }
 
+// finishPause pauses the job once waitFrozen returned err, or thaws it again if it failed or the job was stopped while
+// freezing (caller holds mu).
+func (j *Job) finishPause(err error, by string) error {
+	j.mu.pausing = false
+	if err == nil && j.mu.phase == PhaseRunning {
+		j.setPhase(PhasePaused, by)
+		return nil
+	}
+
+	// The job exited while freezing and its cgroup was removed, which waitFrozen fails on reading
+	if j.mu.exit != nil {
+		return ErrNotRunning
+	}
+
+	if err == nil {
+		// The job was stopped while freezing
+		err = ErrNotRunning
+	}
+
+	return errors.Join(err, freezeCgroup(j.id, false))
+}
+
+// waitFrozen waits until every process in the job's cgroup is frozen.
+func (j *Job) waitFrozen(ctx context.Context) error {
+	ticker := time.NewTicker(freezePollInterval)
+	defer ticker.Stop()
+
+	for {
+		frozen, err := cgroupFrozen(j.id)
+		if err != nil {
+			return fmt.Errorf("read cgroup events: %w", err)
+		}
+
+		if frozen {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Resume thaws every process in a paused job's cgroup.
+//
+// by is recorded as who resumed the job.
+func (j *Job) Resume(by string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.mu.phase != PhasePaused {
+		return ErrNotPaused
+	}
+
+	if err := freezeCgroup(j.id, false); err != nil {
+		return fmt.Errorf("thaw cgroup: %w", err)
+	}
+
+	j.setPhase(PhaseRunning, by)
+	return nil
+}
+
+// UpdateLimits applies new resource limits to a running or paused job's cgroup and returns the job's limits.
+//
+// Unset limits are left unchanged. New IO limits replace the old ones (including the device). Limits are applied one
+// controller at a time so the returned limits match the cgroup even if a write fails part way. New IO limits are
+// applied as a whole and the old ones are restored if a write fails, so only a failed restore (reported in the error)
+// can leave the cgroup's IO limits different from the returned ones.
+func (j *Job) UpdateLimits(update Limits) (Limits, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.active() {
+		return j.mu.limits, ErrNotRunning
+	}
+
+	// Quota and burst are applied together since the kernel requires burst <= quota
+	if update.CPU != nil || update.CPUBurst != nil {
+		if err := applyLimits(j.id, Limits{CPU: update.CPU, CPUBurst: update.CPUBurst}); err != nil {
+			return j.mu.limits, err
+		}
+
+		if update.CPU != nil {
+			j.mu.limits.CPU = update.CPU
+		}
+
+		if update.CPUBurst != nil {
+			j.mu.limits.CPUBurst = update.CPUBurst
+		}
+	}
+
+	if update.CPUWeight != nil {
+		if err := applyLimits(j.id, Limits{CPUWeight: update.CPUWeight}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.CPUWeight = update.CPUWeight
+	}
+
+	if update.CPUs != nil {
+		if err := applyLimits(j.id, Limits{CPUs: update.CPUs}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.CPUs = update.CPUs
+	}
+
+	if update.Mems != nil {
+		if err := applyLimits(j.id, Limits{Mems: update.Mems}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.Mems = update.Mems
+	}
+
+	if update.Memory != nil {
+		if err := applyLimits(j.id, Limits{Memory: update.Memory}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.Memory = update.Memory
+	}
+
+	if update.IO != nil {
+		// Lift the old limits first since a new entry could name the same disk by another path
+		err := resetIOLimits(j.id, j.mu.limits.IO)
+		if err == nil {
+			err = applyLimits(j.id, Limits{IO: update.IO})
+		}
+
+		if err != nil {
+			// Lift whatever part of the new limits was written and put the old ones back
+			if restoreErr := errors.Join(
+				resetIOLimits(j.id, update.IO),
+				applyLimits(j.id, Limits{IO: j.mu.limits.IO}),
+			); restoreErr != nil {
+				err = errors.Join(err, fmt.Errorf("restore io limits: %w", restoreErr))
+			}
+
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.IO = update.IO
+	}
+
+	if update.PIDs != nil {
+		if err := applyLimits(j.id, Limits{PIDs: update.PIDs}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.PIDs = update.PIDs
+	}
+
+	if update.OOMGroup != nil {
+		if err := applyLimits(j.id, Limits{OOMGroup: update.OOMGroup}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.OOMGroup = update.OOMGroup
+	}
+
+	if update.MemoryHigh != nil {
+		if err := applyLimits(j.id, Limits{MemoryHigh: update.MemoryHigh}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.MemoryHigh = update.MemoryHigh
+	}
+
+	if update.MemoryLow != nil {
+		if err := applyLimits(j.id, Limits{MemoryLow: update.MemoryLow}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.MemoryLow = update.MemoryLow
+	}
+
+	if update.MemoryMin != nil {
+		if err := applyLimits(j.id, Limits{MemoryMin: update.MemoryMin}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.MemoryMin = update.MemoryMin
+	}
+
+	if update.Swap != nil {
+		if err := applyLimits(j.id, Limits{Swap: update.Swap}); err != nil {
+			return j.mu.limits, err
+		}
+
+		j.mu.limits.Swap = update.Swap
+	}
+
+	return j.mu.limits, nil
+}
+
+// active reports whether the job's process has not been stopped or exited (running or paused).
+//
+// Caller must hold j.mu.
+func (j *Job) active() bool {
+	return j.mu.phase == PhaseRunning || j.mu.phase == PhasePaused
+}
+
+// Signal sends a signal to the job without changing its phase.
+//
+// The signal goes to the process group unless all is set, in which case it goes to every process in the cgroup
+// (including processes that left the process group). Returns the number of processes signaled when all is set.
+func (j *Job) Signal(sig unix.Signal, all bool) (int, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.mu.phase != PhaseRunning {
+		return 0, ErrNotRunning
+	}
+
+	if !all {
+		if err := unix.Kill(-j.cmd.Process.Pid, sig); err != nil {
+			return 0, fmt.Errorf("signal process group (pid=%d): %w", j.cmd.Process.Pid, err)
+		}
+
+		return 0, nil
+	}
+
+	pids, err := readCgroupPIDs(j.id)
+	if err != nil {
+		return 0, fmt.Errorf("read cgroup procs: %w", err)
+	}
+
+	signaled := 0
+	for _, pid := range pids {
+		// The process may have exited since cgroup.procs was read
+		if err := unix.Kill(pid, sig); err != nil {
+			if errors.Is(err, unix.ESRCH) {
+				continue
+			}
+
+			return signaled, fmt.Errorf("signal process (pid=%d): %w", pid, err)
+		}
+
+		signaled++
+	}
+
+	return signaled, nil
+}
+
+// WriteInput writes data to the job's stdin.
+func (j *Job) WriteInput(data []byte) (int, error) {
+	if j.stdin == nil {
+		return 0, ErrNoStdin
+	}
+
+	return j.stdin.Write(data)
+}
+
+// CloseInput closes the job's stdin so the process reads EOF.
+//
+// Under a terminal this sends the EOF character instead since closing the terminal would hang up the job.
+func (j *Job) CloseInput() error {
+	if j.tty != nil {
+		_, err := j.tty.Write([]byte{eofChar})
+		return err
+	}
+
+	return j.closeStdin()
+}
+
+// Resize sets the window size of the job's terminal.
+func (j *Job) Resize(rows, cols uint16) error {
+	if j.tty == nil {
+		return ErrNoTTY
+	}
+
+	return resizePTY(j.tty, rows, cols)
+}
+
+// closeStdin closes the job's stdin pipe or terminal.
+//
+// Closing an already closed stdin is a no-op.
+func (j *Job) closeStdin() error {
+	if j.stdin == nil {
+		return nil
+	}
+
+	if err := j.stdin.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stats samples the job's current resource usage from its cgroup.
+//
+// Once the process has exited the final usage is returned (marked Final). Returns ErrNotRunning if the final usage
+// could not be taken.
+func (j *Job) Stats() (Stats, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.mu.finished.IsZero() {
+		if j.mu.usage == nil {
+			return Stats{}, ErrNotRunning
+		}
+
+		return j.mu.usage.clone(), nil
+	}
+
+	return readStats(j.id)
+}
+
+// Usage returns the job's final resource usage or nil if the process has not exited.
+func (j *Job) Usage() *Stats {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.mu.usage == nil {
+		return nil
+	}
+
+	usage := j.mu.usage.clone()
+	return &usage
+}
+
+// Done returns a channel that is closed once the job's process has exited and its resources are cleaned up.
+func (j *Job) Done() <-chan struct{} { return j.done }
+
+// ReadOptions selects the output a reader reads.
+type ReadOptions struct {
+	// Stream only reads output written to one stream (StreamUnknown reads all of them).
+	Stream Stream
+	// Since starts at the first write that arrived at or after it (zero starts at the beginning).
+	Since time.Time
+	// Offset starts at this byte of the output, counting every byte ever written.
+	Offset int64
+	// TailBytes starts this many bytes before the end of the output.
+	TailBytes int64
+	// TailLines starts this many lines before the end of the output.
+	TailLines int64
+	// NoFollow stops at the end of the output written when reading starts instead of waiting for more.
+	NoFollow bool
+}
+
+// NewReader returns a reader for the job's output. Close it to release any output file it has open.
+//
+// Only one of Since, Offset, TailBytes and TailLines should be set. With none of them the reader starts at the beginning
+// of the output.
+//
+// If output the reader hasn't read yet is dropped over the output limit, Read returns a *TruncatedError and continues
+// from the oldest kept byte.
+func (j *Job) NewReader(ctx context.Context, opts ReadOptions) *OutputReader {
+	return newOutputReader(ctx, j.output, opts)
+}
+
+// OutputDropped returns the number of output bytes dropped over the output limit.
+func (j *Job) OutputDropped() int64 { return j.output.Dropped() }
+
 // ID returns the job's ID.
 func (j *Job) ID() string { return j.id }
 
@@ -158,8 +861,76 @@ func (j *Job) Args() []string { return j.args }
 // Owner returns the job's owner.
 func (j *Job) Owner() string { return j.owner }
 
-// Limits returns the job's resource limits.
-func (j *Job) Limits() Limits { return j.limits }
+// Dir returns the job's working directory (empty if it uses the server's working directory).
+func (j *Job) Dir() string { return j.dir }
+
+// Credential returns the user and groups the job runs as (nil if it runs as the server's user).
+func (j *Job) Credential() *syscall.Credential {
+	if j.cred == nil {
+		return nil
+	}
+
+	cred := *j.cred
+	cred.Groups = slices.Clone(j.cred.Groups)
+	return &cred
+}
+
+// Timeout returns the job's max runtime (0 if it never times out).
+func (j *Job) Timeout() time.Duration { return j.timeout }
+
+// StopSignal returns the signal sent to the process group when the job is stopped.
+func (j *Job) StopSignal() unix.Signal { return j.stopSignal }
+
+// StopGrace returns how long a stop waits after the stop signal before the cgroup is killed.
+func (j *Job) StopGrace() time.Duration { return j.stopGrace }
+
+// TTY returns true if the job runs under a terminal.
+func (j *Job) TTY() bool { return j.tty != nil }
+
+// Limits returns the job's current resource limits.
+func (j *Job) Limits() Limits {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.mu.limits
+}
+
+// Created returns when the job was created.
+func (j *Job) Created() time.Time { return j.created }
+
+// Started returns when the job's process was started.
+func (j *Job) Started() time.Time { return j.started }
+
+// Finished returns when the job's process exited or the zero time if it is still running.
+func (j *Job) Finished() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.mu.finished
+}
+
+// Transitions returns the job's phase transitions in order.
+func (j *Job) Transitions() []Transition {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return slices.Clone(j.mu.transitions)
+}
+
+// PIDsMaxEvents returns how many times the job failed to fork because it hit its pids limit.
+func (j *Job) PIDsMaxEvents() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// The cgroup is removed once the process exits so use the snapshot
+	if !j.mu.finished.IsZero() {
+		return j.mu.pidsMaxEvents
+	}
+
+	events, err := readCgroupStats(j.id, "pids.events")
+	if err != nil {
+		return 0
+	}
+
+	return events["max"]
+}
 
 // Err returns the job's error after it has exited.
 func (j *Job) Err() error {
@@ -168,6 +939,26 @@ func (j *Job) Err() error {
 	return j.mu.err
 }
 
+// Exit returns the job's exit status or nil if it has not exited.
+func (j *Job) Exit() *ExitStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.mu.exit == nil {
+		return nil
+	}
+
+	exit := *j.mu.exit
+	return &exit
+}
+
+// Active returns true if the job has not been stopped or exited (running or paused).
+func (j *Job) Active() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.active()
+}
+
 // Phase returns the job's current lifecycle phase.
 func (j *Job) Phase() Phase {
 	j.mu.Lock()
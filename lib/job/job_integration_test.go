@@ -8,8 +8,11 @@ import (
 	"io"
 	"os"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
+
+	"golang.org/x/sys/unix"
 )
 
 func waitPhase(t *testing.T, j *Job, want Phase, timeout time.Duration) {
@@ -34,12 +37,12 @@ func cgroupExists(id string) bool {
 }
 
 func TestJob_Lifecycle(t *testing.T) {
-	j, err := New("echo", []string{"hello"}, "test", Limits{})
+	j, err := New("echo", []string{"hello"}, "test", Options{})
 	if err != nil {
 		t.Fatalf("New: %v", err)
 	}
 
-	defer j.Stop(context.Background())
+	defer j.Stop(context.Background(), 0, "test")
 
 	waitPhase(t, j, PhaseCompleted, 2*time.Second)
 
@@ -47,7 +50,7 @@ func TestJob_Lifecycle(t *testing.T) {
 		t.Fatal("cgroup dir still exists after completion")
 	}
 
-	got, err := io.ReadAll(j.NewReader(context.Background()))
+	got, err := io.ReadAll(j.NewReader(context.Background(), ReadOptions{}))
 	if err != nil {
 		t.Fatalf("ReadAll: %v", err)
 	}
@@ -58,16 +61,16 @@ func TestJob_Lifecycle(t *testing.T) {
 }
 
 func TestJob_Output(t *testing.T) {
-	j, err := New("sh", []string{"-c", "echo one; echo two; echo three"}, "test", Limits{})
+	j, err := New("sh", []string{"-c", "echo one; echo two; echo three"}, "test", Options{})
 	if err != nil {
 		t.Fatalf("New: %v", err)
 	}
 
-	defer j.Stop(context.Background())
+	defer j.Stop(context.Background(), 0, "test")
 
 	waitPhase(t, j, PhaseCompleted, 2*time.Second)
 
-	got, err := io.ReadAll(j.NewReader(context.Background()))
+	got, err := io.ReadAll(j.NewReader(context.Background(), ReadOptions{}))
 	if err != nil {
 		t.Fatalf("ReadAll: %v", err)
 	}
@@ -78,13 +81,194 @@ func TestJob_Output(t *testing.T) {
 	}
 }
 
+func TestJob_OutputStreams(t *testing.T) {
+	j, err := New("sh", []string{"-c", "echo out1; echo err1 >&2; echo out2; echo err2 >&2"}, "test", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	waitPhase(t, j, PhaseCompleted, 2*time.Second)
+
+	for _, tc := range []struct {
+		stream Stream
+		want   string
+	}{
+		{StreamStdout, "out1\nout2\n"},
+		{StreamStderr, "err1\nerr2\n"},
+	} {
+		got, err := io.ReadAll(j.NewReader(context.Background(), ReadOptions{Stream: tc.stream}))
+		if err != nil {
+			t.Fatalf("ReadAll: %v", err)
+		}
+
+		if string(got) != tc.want {
+			t.Fatalf("stream %d output (got=%q, want=%q)", tc.stream, string(got), tc.want)
+		}
+	}
+}
+
+func TestJob_EnvAndDir(t *testing.T) {
+	dir := t.TempDir()
+	j, err := New("/bin/sh", []string{"-c", "echo $FOO; echo $HOME; pwd"}, "test", Options{
+		Env: []string{"FOO=bar"},
+		Dir: dir,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	waitPhase(t, j, PhaseCompleted, 2*time.Second)
+
+	got, err := io.ReadAll(j.NewReader(context.Background(), ReadOptions{}))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+
+	// HOME is not inherited from the test process
+	want := "bar\n\n" + dir + "\n"
+	if string(got) != want {
+		t.Fatalf("output (got=%q, want=%q)", string(got), want)
+	}
+}
+
+func TestJob_Credential(t *testing.T) {
+	j, err := New("/bin/sh", []string{"-c", "id -u; id -g; id -G"}, "test", Options{
+		Credential: &syscall.Credential{Uid: 65534, Gid: 65534},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	waitPhase(t, j, PhaseCompleted, 2*time.Second)
+
+	got, err := io.ReadAll(j.NewReader(context.Background(), ReadOptions{}))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+
+	// The server's supplementary groups are dropped
+	want := "65534\n65534\n65534\n"
+	if string(got) != want {
+		t.Fatalf("output (got=%q, want=%q)", string(got), want)
+	}
+}
+
+func TestJob_ExitStatus(t *testing.T) {
+	j, err := New("sh", []string{"-c", "exit 3"}, "test", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	waitPhase(t, j, PhaseCompleted, 2*time.Second)
+
+	exit := j.Exit()
+	if exit == nil {
+		t.Fatal("exit status (got=nil, want=non-nil)")
+	}
+
+	if exit.Code != 3 {
+		t.Fatalf("exit code (got=%d, want=3)", exit.Code)
+	}
+
+	if j.Err() == nil {
+		t.Fatal("Err (got=nil, want=non-nil)")
+	}
+}
+
+func TestJob_Stdin(t *testing.T) {
+	j, err := New("cat", nil, "test", Options{Stdin: true})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	if _, err := j.WriteInput([]byte("hello\n")); err != nil {
+		t.Fatalf("WriteInput: %v", err)
+	}
+
+	if err := j.CloseInput(); err != nil {
+		t.Fatalf("CloseInput: %v", err)
+	}
+
+	// cat exits once it reads EOF
+	waitPhase(t, j, PhaseCompleted, 2*time.Second)
+
+	got, err := io.ReadAll(j.NewReader(context.Background(), ReadOptions{}))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+
+	if string(got) != "hello\n" {
+		t.Fatalf("output (got=%q, want=%q)", string(got), "hello\n")
+	}
+}
+
+func TestJob_TTY(t *testing.T) {
+	j, err := New("sh", []string{"-c", "test -t 0 && test -t 1 && echo tty; stty size; read line; echo got $line"}, "test", Options{
+		TTY: true,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	if err := j.Resize(40, 100); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+
+	if _, err := j.WriteInput([]byte("hello\n")); err != nil {
+		t.Fatalf("WriteInput: %v", err)
+	}
+
+	waitPhase(t, j, PhaseCompleted, 2*time.Second)
+
+	got, err := io.ReadAll(j.NewReader(context.Background(), ReadOptions{}))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+
+	// The terminal echoes input and translates \n to \r\n
+	for _, want := range []string{"tty\r\n", "40 100\r\n", "got hello\r\n"} {
+		if !strings.Contains(string(got), want) {
+			t.Fatalf("output (got=%q, want to contain %q)", string(got), want)
+		}
+	}
+}
+
+func TestJob_NoStdin(t *testing.T) {
+	j, err := New("sleep", []string{"60"}, "test", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	if _, err := j.WriteInput([]byte("hello")); !errors.Is(err, ErrNoStdin) {
+		t.Fatalf("WriteInput (got=%v, want=ErrNoStdin)", err)
+	}
+
+	if err := j.Resize(40, 100); !errors.Is(err, ErrNoTTY) {
+		t.Fatalf("Resize (got=%v, want=ErrNoTTY)", err)
+	}
+}
+
 func TestJob_Stop(t *testing.T) {
-	j, err := New("sleep", []string{"60"}, "test", Limits{})
+	j, err := New("sleep", []string{"60"}, "test", Options{})
 	if err != nil {
 		t.Fatalf("New: %v", err)
 	}
 
-	defer j.Stop(context.Background())
+	defer j.Stop(context.Background(), 0, "test")
 
 	if j.Phase() != PhaseRunning {
 		t.Fatalf("phase after New (got=%d, want=%d)", j.Phase(), PhaseRunning)
@@ -93,7 +277,7 @@ func TestJob_Stop(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	if err := j.Stop(ctx); err != nil {
+	if err := j.Stop(ctx, 0, "test"); err != nil {
 		t.Fatalf("Stop: %v", err)
 	}
 
@@ -101,28 +285,248 @@ func TestJob_Stop(t *testing.T) {
 		t.Fatalf("phase after Stop (got=%d, want=%d)", j.Phase(), PhaseStopped)
 	}
 
+	transitions := j.Transitions()
+	if len(transitions) != 2 {
+		t.Fatalf("transitions (got=%d, want=2)", len(transitions))
+	}
+
+	last := transitions[1]
+	if last.From != PhaseRunning || last.To != PhaseStopped || last.By != "test" {
+		t.Fatalf("stop transition (got=%+v, want=running -> stopped by test)", last)
+	}
+
+	if j.Finished().IsZero() {
+		t.Fatal("finished time not set after Stop")
+	}
+
 	if cgroupExists(j.ID()) {
 		t.Fatal("cgroup dir still exists after stop")
 	}
 }
 
+func TestJob_Timeout(t *testing.T) {
+	j, err := New("sleep", []string{"60"}, "test", Options{Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	waitPhase(t, j, PhaseTimedOut, 2*time.Second)
+
+	// Wait for the process to exit
+	io.Copy(io.Discard, j.NewReader(context.Background(), ReadOptions{}))
+
+	last := j.Transitions()[1]
+	if last.From != PhaseRunning || last.To != PhaseTimedOut || last.By != "timeout" {
+		t.Fatalf("timeout transition (got=%+v, want=running -> timed out by timeout)", last)
+	}
+
+	if j.Err() != nil {
+		t.Fatalf("Err (got=%v, want=nil)", j.Err())
+	}
+
+	if cgroupExists(j.ID()) {
+		t.Fatal("cgroup dir still exists after timeout")
+	}
+}
+
+func TestJob_StopSignal(t *testing.T) {
+	j, err := New("sleep", []string{"60"}, "test", Options{StopSignal: unix.SIGINT})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := j.Stop(ctx, 0, "test"); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	if exit := j.Exit(); exit == nil || exit.Signal != unix.SIGINT {
+		t.Fatalf("exit status (got=%+v, want signal=SIGINT)", exit)
+	}
+}
+
+func TestJob_Signal(t *testing.T) {
+	// The shell prints on SIGHUP and keeps running
+	j, err := New("sh", []string{"-c", "trap 'echo hup' HUP; echo ready; while true; do sleep 0.1; done"}, "test", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	reader := j.NewReader(context.Background(), ReadOptions{})
+	buf := make([]byte, 16)
+	reader.Read(buf)
+
+	if _, err := j.Signal(unix.SIGHUP, false); err != nil {
+		t.Fatalf("Signal: %v", err)
+	}
+
+	n, _ := reader.Read(buf)
+	if string(buf[:n]) != "hup\n" {
+		t.Fatalf("output (got=%q, want=%q)", string(buf[:n]), "hup\n")
+	}
+
+	signaled, err := j.Signal(unix.SIGCONT, true)
+	if err != nil {
+		t.Fatalf("Signal all: %v", err)
+	}
+
+	if signaled == 0 {
+		t.Fatal("signaled (got=0, want>0)")
+	}
+
+	if j.Phase() != PhaseRunning {
+		t.Fatalf("phase after Signal (got=%d, want=%d)", j.Phase(), PhaseRunning)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := j.Stop(ctx, 0, "test"); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	if _, err := j.Signal(unix.SIGHUP, false); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("Signal after Stop (got=%v, want=ErrNotRunning)", err)
+	}
+}
+
+func TestJob_PauseResume(t *testing.T) {
+	j, err := New("sleep", []string{"60"}, "test", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := j.Pause(ctx, "test"); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+
+	if j.Phase() != PhasePaused {
+		t.Fatalf("phase after Pause (got=%d, want=%d)", j.Phase(), PhasePaused)
+	}
+
+	if got := readCgroupFile(t, j.ID(), "cgroup.freeze"); got != "1" {
+		t.Fatalf("cgroup.freeze (got=%q, want=%q)", got, "1")
+	}
+
+	if err := j.Pause(ctx, "test"); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("Pause while paused (got=%v, want=ErrNotRunning)", err)
+	}
+
+	if err := j.Resume("test"); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+
+	if j.Phase() != PhaseRunning {
+		t.Fatalf("phase after Resume (got=%d, want=%d)", j.Phase(), PhaseRunning)
+	}
+
+	if err := j.Resume("test"); !errors.Is(err, ErrNotPaused) {
+		t.Fatalf("Resume while running (got=%v, want=ErrNotPaused)", err)
+	}
+}
+
+func TestJob_StopPaused(t *testing.T) {
+	j, err := New("sleep", []string{"60"}, "test", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := j.Pause(ctx, "test"); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+
+	// The frozen process still gets the stop signal once thawed
+	if err := j.Stop(ctx, 0, "test"); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	if j.Phase() != PhaseStopped {
+		t.Fatalf("phase after Stop (got=%d, want=%d)", j.Phase(), PhaseStopped)
+	}
+
+	if exit := j.Exit(); exit == nil || exit.Signal != unix.SIGTERM {
+		t.Fatalf("exit status (got=%+v, want signal=SIGTERM)", exit)
+	}
+}
+
+func TestJob_Stats(t *testing.T) {
+	j, err := New("sleep", []string{"60"}, "test", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	defer j.Stop(context.Background(), 0, "test")
+
+	stats, err := j.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+
+	if stats.PIDs.Current != 1 {
+		t.Fatalf("pids current (got=%d, want=1)", stats.PIDs.Current)
+	}
+
+	if stats.Memory.Current == 0 {
+		t.Fatal("memory current (got=0, want>0)")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := j.Stop(ctx, 0, "test"); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	// The final usage is kept after the cgroup is removed
+	final, err := j.Stats()
+	if err != nil {
+		t.Fatalf("Stats after Stop: %v", err)
+	}
+
+	if !final.Final || final.CPU.UsageUsec == 0 {
+		t.Fatalf("final stats (got=%+v, want final with cpu usage)", final)
+	}
+
+	if usage := j.Usage(); usage == nil || usage.CPU.UsageUsec != final.CPU.UsageUsec {
+		t.Fatalf("usage (got=%+v, want=%+v)", usage, final)
+	}
+}
+
 func TestJob_Kill(t *testing.T) {
 	// The shell sets up a trap to ignore SIGTERM so it will skip to force kill
-	j, err := New("sh", []string{"-c", "trap '' TERM; echo ready; while true; do sleep 60; done"}, "test", Limits{})
+	j, err := New("sh", []string{"-c", "trap '' TERM; echo ready; while true; do sleep 60; done"}, "test", Options{})
 	if err != nil {
 		t.Fatalf("New: %v", err)
 	}
 
-	defer j.Stop(context.Background())
+	defer j.Stop(context.Background(), 0, "test")
 
 	// Wait for the shell to set up the trap before sending SIGTERM
 	buf := make([]byte, 16)
-	j.NewReader(context.Background()).Read(buf)
+	j.NewReader(context.Background(), ReadOptions{}).Read(buf)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
-	err = j.Stop(ctx)
+	err = j.Stop(ctx, 0, "test")
 	if !errors.Is(err, context.DeadlineExceeded) {
 		t.Fatalf("Stop error (got=%v, want=context.DeadlineExceeded)", err)
 	}
@@ -0,0 +1,134 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestNewExitStatus(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		script     string
+		wantReason ExitReason
+		wantCode   int
+		wantSignal unix.Signal
+	}{
+		{"success", "exit 0", ExitReasonExited, 0, 0},
+		{"failure", "exit 3", ExitReasonExited, 3, 0},
+		{"killed", "kill -KILL $$", ExitReasonSignaled, -1, unix.SIGKILL},
+		{"terminated", "kill -TERM $$", ExitReasonSignaled, -1, unix.SIGTERM},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := exec.Command("sh", "-c", tc.script)
+			_ = cmd.Run()
+
+			exit := newExitStatus(cmd.ProcessState)
+			if exit == nil {
+				t.Fatal("newExitStatus (got=nil, want=non-nil)")
+			}
+
+			if exit.Reason != tc.wantReason {
+				t.Errorf("reason (got=%d, want=%d)", exit.Reason, tc.wantReason)
+			}
+
+			if exit.Code != tc.wantCode {
+				t.Errorf("code (got=%d, want=%d)", exit.Code, tc.wantCode)
+			}
+
+			if exit.Signal != tc.wantSignal {
+				t.Errorf("signal (got=%v, want=%v)", exit.Signal, tc.wantSignal)
+			}
+		})
+	}
+}
+
+func TestNewExitStatus_NotExited(t *testing.T) {
+	t.Parallel()
+
+	if exit := newExitStatus(nil); exit != nil {
+		t.Fatalf("newExitStatus (got=%+v, want=nil)", exit)
+	}
+}
+
+func TestExitReason(t *testing.T) {
+	t.Parallel()
+
+	exited := ExitStatus{Reason: ExitReasonExited, Code: 137}
+	signaled := ExitStatus{Reason: ExitReasonSignaled, Code: -1, Signal: unix.SIGKILL}
+
+	for _, tc := range []struct {
+		name     string
+		phase    Phase
+		exit     ExitStatus
+		oomKills uint64
+		want     ExitReason
+	}{
+		{"exited", PhaseCompleted, exited, 0, ExitReasonExited},
+		{"signaled", PhaseCompleted, signaled, 0, ExitReasonSignaled},
+		{"oom_killed_main", PhaseCompleted, signaled, 1, ExitReasonOOMKilled},
+		{"oom_killed_child", PhaseCompleted, exited, 1, ExitReasonOOMKilled},
+		{"oom_killed_success", PhaseCompleted, ExitStatus{Reason: ExitReasonExited}, 2, ExitReasonOOMKilled},
+		{"stopped", PhaseStopped, signaled, 1, ExitReasonSignaled},
+		{"timed_out", PhaseTimedOut, exited, 1, ExitReasonExited},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := exitReason(tc.phase, tc.exit, tc.oomKills); got != tc.want {
+				t.Errorf("reason (got=%d, want=%d)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFinishPause(t *testing.T) {
+	t.Parallel()
+
+	// The cgroup is gone once the job exited, so reading its events fails
+	removed := fmt.Errorf("read cgroup events: %w", fs.ErrNotExist)
+
+	for _, tc := range []struct {
+		name      string
+		phase     Phase
+		exit      *ExitStatus
+		err       error
+		want      error
+		wantPhase Phase
+	}{
+		{"frozen", PhaseRunning, nil, nil, nil, PhasePaused},
+		{"exited_while_freezing", PhaseCompleted, &ExitStatus{Reason: ExitReasonExited}, removed, ErrNotRunning, PhaseCompleted},
+		{"stopped_while_freezing", PhaseStopped, &ExitStatus{Reason: ExitReasonSignaled}, nil, ErrNotRunning, PhaseStopped},
+		{"canceled_after_exit", PhaseCompleted, &ExitStatus{Reason: ExitReasonExited}, context.Canceled, ErrNotRunning, PhaseCompleted},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			j := &Job{id: "job"}
+			j.mu.phase = tc.phase
+			j.mu.exit = tc.exit
+			j.mu.pausing = true
+
+			if err := j.finishPause(tc.err, "user"); !errors.Is(err, tc.want) {
+				t.Fatalf("finishPause (got=%v, want=%v)", err, tc.want)
+			}
+
+			if j.mu.phase != tc.wantPhase {
+				t.Fatalf("phase (got=%v, want=%v)", j.mu.phase, tc.wantPhase)
+			}
+
+			if j.mu.pausing {
+				t.Fatal("pausing (got=true, want=false)")
+			}
+		})
+	}
+}
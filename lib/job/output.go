@@ -2,35 +2,159 @@ package job
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
 	"sync"
+	"time"
+)
+
+const (
+	// outputWindow is the number of recent output bytes kept in memory when output spills to disk.
+	outputWindow = 1 << 20
+	// outputSegmentSize is the size at which a new output segment file is started.
+	outputSegmentSize = 4 << 20
+	// recordSize is the size of a record in a segment's index file (offset, time, stream and padding).
+	recordSize = 24
 )
 
 var (
 	// Compile time verification that outputBuffer implements io.WriteCloser.
 	_ io.WriteCloser = (*outputBuffer)(nil)
-	// Compile time verification that outputReader implements io.Reader.
-	_ io.Reader = (*outputReader)(nil)
+	// Compile time verification that OutputReader implements io.ReadCloser.
+	_ io.ReadCloser = (*OutputReader)(nil)
+)
+
+// Stream is the stream a piece of output was written to (terminal output is stdout).
+type Stream int
+
+const (
+	StreamUnknown Stream = iota
+	StreamStdout
+	StreamStderr
 )
 
+// TruncatedError is returned by an output reader whose offset fell out of the output buffer. The reader continues from
+// the oldest byte still kept.
+type TruncatedError struct {
+	// Bytes is how many bytes the reader missed.
+	Bytes int64
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("truncated %d bytes", e.Bytes)
+}
+
+// Chunk is a piece of output from a single write.
+type Chunk struct {
+	Stream Stream
+	// Time is when the write arrived.
+	Time time.Time
+	// Offset is the offset of Data's first byte in the output.
+	Offset int64
+	Data   []byte
+}
+
+// outputRecord marks where a write starts in the output, when it arrived and which stream it was written to.
+type outputRecord struct {
+	offset int64
+	time   time.Time
+	stream Stream
+}
+
+// outputSegment is a segment file, the number of records in its index file and the time of its first record.
+type outputSegment struct {
+	start   int64
+	records int64
+	first   time.Time
+}
+
 // outputBuffer is a byte buffer that notifies readers on change.
+//
+// With a limit it keeps only the newest limit bytes and drops the oldest ones, like a ring buffer. Offsets count every
+// byte ever written so readers can tell how much they missed.
+//
+// With a directory every write also goes to append only segment files and only the newest window bytes stay in memory.
+// The limit then drops whole segments, oldest first.
+//
+// Each write is recorded with its arrival time and stream so stdout and stderr can be read apart while keeping their
+// order. The records of the bytes in memory are kept in memory and every segment has an index file of its records.
 type outputBuffer struct {
+	limit int64
+	dir   string
+	// window is the number of bytes kept in memory (0 keeps everything in memory).
+	window      int64
+	segmentSize int64
+
 	mu struct {
 		sync.RWMutex
-		buf        []byte
+		buf []byte
+		// bufStart is the offset of buf[0].
+		bufStart int64
+		// records are the writes overlapping buf, oldest first.
+		records []outputRecord
+		// start is the oldest offset kept (also the number of dropped bytes).
+		start int64
+		// segments are the segment files, oldest first.
+		segments []outputSegment
+		// file and index are the newest segment's files and fileSize is how much has been written to file.
+		file     *os.File
+		index    *os.File
+		fileSize int64
+		// spillErr is the disk error that stopped output from being spilled at spillEnd. Later writes are only kept
+		// in memory.
+		spillErr   error
+		spillEnd   int64
 		closed     bool
 		notifyChan chan struct{}
 	}
 }
 
-func newOutputBuffer() *outputBuffer {
-	ob := &outputBuffer{}
+// newOutputBuffer returns a memory only buffer that keeps at most limit bytes (0 = unlimited).
+func newOutputBuffer(limit int64) *outputBuffer {
+	ob := &outputBuffer{limit: limit, window: limit}
 	ob.mu.notifyChan = make(chan struct{})
 	return ob
 }
 
-// Write appends data to the buffer and notifies readers.
+// newSpillOutputBuffer returns a buffer that writes its output to segment files in dir and keeps at most limit bytes of
+// them (0 = unlimited).
+func newSpillOutputBuffer(dir string, limit int64) (*outputBuffer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create output directory (dir=%s): %w", dir, err)
+	}
+
+	ob := newOutputBuffer(limit)
+	ob.dir = dir
+	ob.window = outputWindow
+	ob.segmentSize = outputSegmentSize
+	if limit > 0 {
+		// Only whole segments are dropped and never the newest, so a limit is only kept to when it holds a few of them
+		ob.window = min(outputWindow, limit)
+		ob.segmentSize = min(outputSegmentSize, max(limit/4, 1))
+	}
+
+	return ob, nil
+}
+
+// Write appends stdout data to the buffer (see WriteStream).
 func (ob *outputBuffer) Write(data []byte) (int, error) {
+	return ob.WriteStream(StreamStdout, data)
+}
+
+// Stream returns a writer for one stream of the buffer.
+func (ob *outputBuffer) Stream(stream Stream) io.Writer {
+	return streamWriter{ob: ob, stream: stream}
+}
+
+// WriteStream appends data written to stream to the buffer, drops the oldest bytes over the limit and notifies readers.
+func (ob *outputBuffer) WriteStream(stream Stream, data []byte) (int, error) {
 	if len(data) == 0 {
 		return 0, nil
 	}
@@ -42,13 +166,180 @@ func (ob *outputBuffer) Write(data []byte) (int, error) {
 		return 0, io.ErrClosedPipe
 	}
 
+	record := outputRecord{offset: ob.mu.bufStart + int64(len(ob.mu.buf)), time: time.Now(), stream: stream}
+	if ob.dir != "" && ob.mu.spillErr == nil {
+		// The job's output is never failed over a disk error since the job would block on a full pipe
+		if err := ob.writeSegment(record, data); err != nil {
+			ob.mu.spillErr = err
+			ob.mu.spillEnd = record.offset
+		}
+	}
+
 	ob.mu.buf = append(ob.mu.buf, data...)
+	ob.mu.records = append(ob.mu.records, record)
+	// Reslicing leaves the dropped bytes to the next append that grows the array, so memory stays around twice the window
+	if over := int64(len(ob.mu.buf)) - ob.window; ob.window > 0 && over > 0 {
+		ob.mu.buf = ob.mu.buf[over:]
+		ob.mu.bufStart += over
+	}
+
+	// Keep the record of the oldest byte in memory even if it starts before it
+	drop := 0
+	for drop+1 < len(ob.mu.records) && ob.mu.records[drop+1].offset <= ob.mu.bufStart {
+		drop++
+	}
+
+	ob.mu.records = ob.mu.records[drop:]
+
+	// Without spilling the oldest byte kept is in memory. After spilling stopped that is also the case once output that
+	// was never spilled has left memory.
+	if ob.dir == "" || (ob.mu.spillErr != nil && ob.mu.bufStart > ob.mu.spillEnd) {
+		ob.mu.start = ob.mu.bufStart
+	}
+
 	close(ob.mu.notifyChan)
 	ob.mu.notifyChan = make(chan struct{})
 
 	return len(data), nil
 }
 
+// writeSegment appends a write to the newest segment file and its record to the segment's index file, starting a new
+// segment when it is full and removing the oldest ones over the limit (caller holds mu).
+//
+// The record is only written once the data is, and a failed write is truncated off both files so the segment files
+// never get ahead of the output in memory.
+func (ob *outputBuffer) writeSegment(record outputRecord, data []byte) error {
+	if ob.mu.file == nil || ob.mu.fileSize >= ob.segmentSize {
+		if err := ob.closeSegment(); err != nil {
+			return err
+		}
+
+		if err := ob.createSegment(record.offset, record.time); err != nil {
+			return err
+		}
+	}
+
+	segment := &ob.mu.segments[len(ob.mu.segments)-1]
+	if _, err := ob.mu.file.Write(data); err != nil {
+		return errors.Join(fmt.Errorf("write output segment: %w", err), ob.truncateSegment())
+	}
+
+	if _, err := ob.mu.index.Write(encodeRecord(record)); err != nil {
+		return errors.Join(fmt.Errorf("write output index: %w", err), ob.truncateSegment())
+	}
+
+	segment.records++
+	ob.mu.fileSize += int64(len(data))
+
+	// Never remove the segment being written to
+	end := record.offset + int64(len(data))
+	for ob.limit > 0 && len(ob.mu.segments) > 1 && end-ob.mu.segments[0].start > ob.limit {
+		// A segment that can't be removed is tried again on the next write
+		if err := ob.removeSegment(ob.mu.segments[0].start); err != nil {
+			break
+		}
+
+		ob.mu.segments = ob.mu.segments[1:]
+		ob.mu.start = ob.mu.segments[0].start
+	}
+
+	return nil
+}
+
+// truncateSegment cuts the newest segment's files back to the writes recorded in them, removing the segment if it has
+// none (caller holds mu).
+func (ob *outputBuffer) truncateSegment() error {
+	segment := ob.mu.segments[len(ob.mu.segments)-1]
+	if segment.records == 0 {
+		err := errors.Join(ob.closeSegment(), ob.removeSegment(segment.start))
+		ob.mu.segments = ob.mu.segments[:len(ob.mu.segments)-1]
+		return err
+	}
+
+	if err := errors.Join(ob.mu.file.Truncate(ob.mu.fileSize), ob.mu.index.Truncate(segment.records*recordSize)); err != nil {
+		return fmt.Errorf("truncate output segment: %w", err)
+	}
+
+	return nil
+}
+
+// createSegment creates the files of a new segment starting at offset with its first record at first (caller holds
+// mu).
+func (ob *outputBuffer) createSegment(offset int64, first time.Time) error {
+	flags := os.O_CREATE | os.O_EXCL | os.O_WRONLY | os.O_APPEND
+	file, err := os.OpenFile(ob.segmentPath(offset), flags, 0o600)
+	if err != nil {
+		return fmt.Errorf("create output segment: %w", err)
+	}
+
+	index, err := os.OpenFile(ob.indexPath(offset), flags, 0o600)
+	if err != nil {
+		return errors.Join(fmt.Errorf("create output index: %w", err), file.Close())
+	}
+
+	ob.mu.file = file
+	ob.mu.index = index
+	ob.mu.fileSize = 0
+	ob.mu.segments = append(ob.mu.segments, outputSegment{start: offset, first: first})
+
+	return nil
+}
+
+// closeSegment closes the newest segment's files (caller holds mu).
+func (ob *outputBuffer) closeSegment() error {
+	if ob.mu.file == nil {
+		return nil
+	}
+
+	err := errors.Join(ob.mu.file.Close(), ob.mu.index.Close())
+	ob.mu.file = nil
+	ob.mu.index = nil
+	if err != nil {
+		return fmt.Errorf("close output segment: %w", err)
+	}
+
+	return nil
+}
+
+// removeSegment removes the files of the segment starting at offset.
+func (ob *outputBuffer) removeSegment(offset int64) error {
+	for _, path := range []string{ob.segmentPath(offset), ob.indexPath(offset)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove output segment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// segmentPath returns the path of the segment file starting at offset.
+func (ob *outputBuffer) segmentPath(offset int64) string {
+	return filepath.Join(ob.dir, fmt.Sprintf("%020d.log", offset))
+}
+
+// indexPath returns the path of the index file of the segment starting at offset.
+func (ob *outputBuffer) indexPath(offset int64) string {
+	return filepath.Join(ob.dir, fmt.Sprintf("%020d.idx", offset))
+}
+
+// encodeRecord encodes a record for an index file.
+func encodeRecord(record outputRecord) []byte {
+	buf := make([]byte, recordSize)
+	binary.LittleEndian.PutUint64(buf, uint64(record.offset))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(record.time.UnixNano()))
+	buf[16] = byte(record.stream)
+	return buf
+}
+
+// decodeRecord decodes a record from an index file.
+func decodeRecord(buf []byte) outputRecord {
+	return outputRecord{
+		offset: int64(binary.LittleEndian.Uint64(buf)),
+		time:   time.Unix(0, int64(binary.LittleEndian.Uint64(buf[8:]))),
+		stream: Stream(buf[16]),
+	}
+}
+
 // Close marks the buffer as closed and notifies readers.
 func (ob *outputBuffer) Close() error {
 	ob.mu.Lock()
@@ -61,42 +352,155 @@ func (ob *outputBuffer) Close() error {
 	ob.mu.closed = true
 	close(ob.mu.notifyChan)
 
-	return nil
+	return ob.closeSegment()
 }
 
-// outputReader reads from the beginning of an outputBuffer.
-type outputReader struct {
+// Remove closes the buffer and removes its directory.
+func (ob *outputBuffer) Remove() error {
+	err := ob.Close()
+	if ob.dir == "" {
+		return err
+	}
+
+	if removeErr := os.RemoveAll(ob.dir); removeErr != nil {
+		err = errors.Join(err, fmt.Errorf("remove output directory (dir=%s): %w", ob.dir, removeErr))
+	}
+
+	return err
+}
+
+// Dropped returns the number of bytes dropped over the limit.
+func (ob *outputBuffer) Dropped() int64 {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.mu.start
+}
+
+// streamWriter writes to one stream of an outputBuffer.
+type streamWriter struct {
+	ob     *outputBuffer
+	stream Stream
+}
+
+func (sw streamWriter) Write(data []byte) (int, error) {
+	return sw.ob.WriteStream(sw.stream, data)
+}
+
+// OutputReader reads a job's output from the beginning.
+type OutputReader struct {
 	ctx context.Context
 
 	ob     *outputBuffer
-	offset int
+	offset int64
+	opts   ReadOptions
+	// seeked is true once the reader moved to where opts start reading.
+	seeked bool
+	// end is where the reader stops with opts.NoFollow.
+	end int64
+
+	// file and index are the open files of the segment starting at fileStart that the reader is reading from disk.
+	file      *os.File
+	index     *os.File
+	fileStart int64
 }
 
-func newOutputReader(ctx context.Context, ob *outputBuffer) *outputReader {
-	return &outputReader{ctx: ctx, ob: ob}
+func newOutputReader(ctx context.Context, ob *outputBuffer, opts ReadOptions) *OutputReader {
+	return &OutputReader{ctx: ctx, ob: ob, offset: opts.Offset, opts: opts}
 }
 
-// Read blocks on the outputBuffer until notified of new data, EOF or context cancellation.
-func (or *outputReader) Read(buf []byte) (int, error) {
+// Offset returns the offset of the next byte the reader reads.
+func (or *OutputReader) Offset() int64 {
+	return or.offset
+}
+
+// Read reads output like ReadChunk without the stream.
+func (or *OutputReader) Read(buf []byte) (int, error) {
+	chunk, err := or.ReadChunk(buf)
+	return len(chunk.Data), err
+}
+
+// ReadChunk blocks on the outputBuffer until notified of new data, EOF or context cancellation. The chunk holds data
+// from a single write read into buf.
+//
+// If the reader's offset was dropped it returns a *TruncatedError once and then continues from the oldest kept byte.
+func (or *OutputReader) ReadChunk(buf []byte) (Chunk, error) {
 	if len(buf) == 0 {
-		return 0, nil
+		return Chunk{}, nil
+	}
+
+	if !or.seeked {
+		if err := or.seek(); err != nil {
+			return Chunk{}, err
+		}
+
+		or.seeked = true
 	}
 
 	for {
+		// Stop at the end of the output when reading started
+		if or.opts.NoFollow && or.offset >= or.end {
+			return Chunk{}, io.EOF
+		}
+
 		or.ob.mu.RLock()
+		// dropped data
+		if start := or.ob.mu.start; or.offset < start {
+			missed := start - or.offset
+			or.offset = start
+			or.ob.mu.RUnlock()
+
+			return Chunk{}, &TruncatedError{Bytes: missed}
+		}
+
+		// data that only remains on disk
+		if bufStart := or.ob.mu.bufStart; or.offset < bufStart {
+			segment, end := or.ob.findSegment(or.offset)
+			or.ob.mu.RUnlock()
+
+			chunk, err := or.readSegment(buf, segment, end)
+			if errors.Is(err, fs.ErrNotExist) && or.ob.segmentRemoved(segment.start) {
+				// The segment was removed over the limit so loop back to report the truncation
+				continue
+			}
+
+			if err != nil || len(chunk.Data) > 0 {
+				return chunk, err
+			}
+
+			// skipped a chunk of another stream
+			continue
+		}
+
+		or.closeSegment()
+
 		// new data
-		if or.offset < len(or.ob.mu.buf) {
-			count := copy(buf, or.ob.mu.buf[or.offset:])
-			or.offset += count
+		if end := or.ob.mu.bufStart + int64(len(or.ob.mu.buf)); or.offset < end {
+			records := or.ob.mu.records
+			i := sort.Search(len(records), func(i int) bool { return records[i].offset > or.offset }) - 1
+			recordEnd := end
+			if i+1 < len(records) {
+				recordEnd = records[i+1].offset
+			}
+
+			if or.opts.Stream != StreamUnknown && records[i].stream != or.opts.Stream {
+				or.offset = recordEnd
+				or.ob.mu.RUnlock()
+				continue
+			}
+
+			data := or.ob.mu.buf[or.offset-or.ob.mu.bufStart : recordEnd-or.ob.mu.bufStart]
+			count := copy(buf, data)
+			chunk := Chunk{Stream: records[i].stream, Time: records[i].time, Offset: or.offset, Data: buf[:count]}
+			or.offset += int64(count)
 			or.ob.mu.RUnlock()
 
-			return count, nil
+			return chunk, nil
 		}
 
 		// EOF
 		if or.ob.mu.closed {
 			or.ob.mu.RUnlock()
-			return 0, io.EOF
+			return Chunk{}, io.EOF
 		}
 
 		notifyChan := or.ob.mu.notifyChan
@@ -106,7 +510,389 @@ func (or *outputReader) Read(buf []byte) (int, error) {
 		case <-notifyChan:
 			// loop back to check what the notify was for
 		case <-or.ctx.Done():
-			return 0, or.ctx.Err()
+			return Chunk{}, or.ctx.Err()
+		}
+	}
+}
+
+// segmentRemoved reports whether the segment starting at offset was removed over the limit, as opposed to missing
+// from disk while it should still be there.
+func (ob *outputBuffer) segmentRemoved(offset int64) bool {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return offset < ob.mu.start
+}
+
+// findSegment returns the segment holding offset and the offset it ends at (caller holds mu).
+func (ob *outputBuffer) findSegment(offset int64) (outputSegment, int64) {
+	end := ob.mu.bufStart + int64(len(ob.mu.buf))
+	if ob.mu.spillErr != nil {
+		end = ob.mu.spillEnd
+	}
+
+	for i := len(ob.mu.segments) - 1; i >= 0; i-- {
+		if ob.mu.segments[i].start <= offset {
+			return ob.mu.segments[i], end
+		}
+
+		end = ob.mu.segments[i].start
+	}
+
+	return outputSegment{start: ob.mu.start}, end
+}
+
+// readSegment reads the chunk at the reader's offset from a segment ending at end. The chunk is empty when it was
+// written to a stream the reader skips.
+//
+// Segment files are append only so the bytes and records before end can be read without holding mu.
+func (or *OutputReader) readSegment(buf []byte, segment outputSegment, end int64) (Chunk, error) {
+	if err := or.openSegment(segment.start); err != nil {
+		return Chunk{}, err
+	}
+
+	// Find the last record starting at or before the offset
+	i, err := or.searchIndex(segment.records, func(record outputRecord) bool { return record.offset > or.offset })
+	if err != nil {
+		return Chunk{}, err
+	}
+
+	i--
+	if i < 0 {
+		return Chunk{}, fmt.Errorf("no output record (offset=%d)", or.offset)
+	}
+
+	record, err := or.readRecord(i)
+	if err != nil {
+		return Chunk{}, err
+	}
+
+	recordEnd := end
+	if i+1 < segment.records {
+		next, err := or.readRecord(i + 1)
+		if err != nil {
+			return Chunk{}, err
+		}
+
+		recordEnd = next.offset
+	}
+
+	if or.opts.Stream != StreamUnknown && record.stream != or.opts.Stream {
+		or.offset = recordEnd
+		return Chunk{}, nil
+	}
+
+	buf = buf[:min(int64(len(buf)), recordEnd-or.offset)]
+	count, err := or.file.ReadAt(buf, or.offset-segment.start)
+	if count > 0 {
+		chunk := Chunk{Stream: record.stream, Time: record.time, Offset: or.offset, Data: buf[:count]}
+		or.offset += int64(count)
+		return chunk, nil
+	}
+
+	if err == nil || err == io.EOF {
+		// The bytes before end are written before the lock is released, so this should never happen
+		return Chunk{}, io.ErrUnexpectedEOF
+	}
+
+	return Chunk{}, fmt.Errorf("read output segment: %w", err)
+}
+
+// seek moves the reader to where its options start reading and, with NoFollow, sets where it stops.
+func (or *OutputReader) seek() error {
+	or.ob.mu.RLock()
+	or.end = or.ob.mu.bufStart + int64(len(or.ob.mu.buf))
+	or.ob.mu.RUnlock()
+
+	switch {
+	case !or.opts.Since.IsZero():
+		return or.seekSince(or.opts.Since)
+	case or.opts.TailLines > 0:
+		return or.seekBack(&lineScanner{lines: or.opts.TailLines})
+	case or.opts.TailBytes > 0:
+		return or.seekBack(&byteScanner{bytes: or.opts.TailBytes})
+	}
+
+	return nil
+}
+
+// backScanner scans output backwards to find where the reader should start.
+type backScanner interface {
+	// scan scans data ending at offset end, which comes right before the data scanned last, and returns the offset to
+	// start at once found.
+	scan(data []byte, end int64) (int64, bool)
+}
+
+// lineScanner finds where the last lines lines start.
+type lineScanner struct {
+	lines int64
+	// scanned is true once the last byte was scanned, so a trailing newline doesn't count as a line.
+	scanned bool
+}
+
+func (ls *lineScanner) scan(data []byte, end int64) (int64, bool) {
+	for i := len(data) - 1; i >= 0; i-- {
+		if data[i] != '\n' {
+			ls.scanned = true
+			continue
+		}
+
+		if !ls.scanned {
+			ls.scanned = true
+			continue
+		}
+
+		ls.lines--
+		if ls.lines == 0 {
+			return end - int64(len(data)-i-1), true
+		}
+	}
+
+	return 0, false
+}
+
+// byteScanner finds where the last bytes bytes start.
+type byteScanner struct {
+	bytes int64
+}
+
+func (bs *byteScanner) scan(data []byte, end int64) (int64, bool) {
+	if int64(len(data)) < bs.bytes {
+		bs.bytes -= int64(len(data))
+		return 0, false
+	}
+
+	return end - bs.bytes, true
+}
+
+// seekBack moves the reader to where scanner finds by scanning the output of the reader's stream backwards from the
+// end. It starts at the oldest kept byte if the scanner doesn't find it.
+func (or *OutputReader) seekBack(scanner backScanner) error {
+	or.ob.mu.RLock()
+	start := or.ob.mu.start
+	bufStart := or.ob.mu.bufStart
+	buf := or.ob.mu.buf
+	records := or.ob.mu.records
+
+	// Scan the bytes in memory, newest write first
+	recordEnd := bufStart + int64(len(buf))
+	for i := len(records) - 1; i >= 0 && recordEnd > bufStart; i-- {
+		record := records[i]
+		if or.opts.Stream == StreamUnknown || record.stream == or.opts.Stream {
+			data := buf[max(record.offset, bufStart)-bufStart : recordEnd-bufStart]
+			if offset, ok := scanner.scan(data, recordEnd); ok {
+				or.offset = offset
+				or.ob.mu.RUnlock()
+				return nil
+			}
+		}
+
+		recordEnd = record.offset
+	}
+
+	segments := slices.Clone(or.ob.mu.segments)
+	or.ob.mu.RUnlock()
+
+	// Scan the bytes only on disk, newest segment first
+	segmentEnd := bufStart
+	for i := len(segments) - 1; i >= 0 && segmentEnd > start; i-- {
+		segment := segments[i]
+		if segment.start >= segmentEnd {
+			continue
 		}
+
+		if err := or.openSegment(segment.start); err != nil {
+			if errors.Is(err, fs.ErrNotExist) && or.ob.segmentRemoved(segment.start) {
+				// The rest was removed over the limit so start at the oldest kept byte
+				break
+			}
+
+			return err
+		}
+
+		offset, ok, err := or.scanSegment(scanner, segment, segmentEnd)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			or.offset = offset
+			return nil
+		}
+
+		segmentEnd = segment.start
 	}
+
+	or.offset = start
+	return nil
+}
+
+// scanSegment scans the open segment's writes before end backwards.
+func (or *OutputReader) scanSegment(scanner backScanner, segment outputSegment, end int64) (int64, bool, error) {
+	buf := make([]byte, 4096)
+	recordEnd := end
+	for i := segment.records - 1; i >= 0; i-- {
+		record, err := or.readRecord(i)
+		if err != nil {
+			return 0, false, err
+		}
+
+		// The write is still in memory
+		if record.offset >= recordEnd {
+			continue
+		}
+
+		if or.opts.Stream == StreamUnknown || record.stream == or.opts.Stream {
+			for pieceEnd := recordEnd; pieceEnd > record.offset; {
+				piece := buf[:min(int64(len(buf)), pieceEnd-record.offset)]
+				if _, err := or.file.ReadAt(piece, pieceEnd-int64(len(piece))-segment.start); err != nil {
+					return 0, false, fmt.Errorf("read output segment: %w", err)
+				}
+
+				if offset, ok := scanner.scan(piece, pieceEnd); ok {
+					return offset, true, nil
+				}
+
+				pieceEnd -= int64(len(piece))
+			}
+		}
+
+		recordEnd = record.offset
+	}
+
+	return 0, false, nil
+}
+
+// seekSince moves the reader to the first write that arrived at or after since.
+//
+// Writes are assumed to be recorded in time order, which holds unless the wall clock jumps back.
+func (or *OutputReader) seekSince(since time.Time) error {
+	for {
+		or.ob.mu.RLock()
+		end := or.ob.mu.bufStart + int64(len(or.ob.mu.buf))
+
+		// The oldest write in memory arrived before since, so the first write at or after it is in memory
+		if records := or.ob.mu.records; len(records) > 0 && records[0].time.Before(since) {
+			i := sort.Search(len(records), func(i int) bool { return !records[i].time.Before(since) })
+			or.offset = end
+			if i < len(records) {
+				or.offset = records[i].offset
+			}
+
+			or.ob.mu.RUnlock()
+			return nil
+		}
+
+		// Find the last segment whose first write arrived before since
+		segments := or.ob.mu.segments
+		i := sort.Search(len(segments), func(i int) bool { return !segments[i].first.Before(since) }) - 1
+		if i < 0 {
+			// Everything kept arrived at or after since
+			or.offset = or.ob.mu.start
+			or.ob.mu.RUnlock()
+			return nil
+		}
+
+		segment := segments[i]
+		segmentEnd := end
+		if i+1 < len(segments) {
+			segmentEnd = segments[i+1].start
+		}
+
+		or.ob.mu.RUnlock()
+
+		if err := or.openSegment(segment.start); err != nil {
+			if errors.Is(err, fs.ErrNotExist) && or.ob.segmentRemoved(segment.start) {
+				// The segment was removed over the limit so look again
+				continue
+			}
+
+			return err
+		}
+
+		j, err := or.searchIndex(segment.records, func(record outputRecord) bool { return !record.time.Before(since) })
+		if err != nil {
+			return err
+		}
+
+		or.offset = segmentEnd
+		if j < segment.records {
+			record, err := or.readRecord(j)
+			if err != nil {
+				return err
+			}
+
+			or.offset = record.offset
+		}
+
+		return nil
+	}
+}
+
+// searchIndex returns the index of the first of the open segment's records that found is true for (records if none).
+// found must be false for a prefix of the records and true for the rest.
+func (or *OutputReader) searchIndex(records int64, found func(outputRecord) bool) (int64, error) {
+	var searchErr error
+	i := sort.Search(int(records), func(i int) bool {
+		record, err := or.readRecord(int64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+
+		return found(record)
+	})
+
+	return int64(i), searchErr
+}
+
+// openSegment opens the files of the segment starting at offset unless the reader already has them open.
+func (or *OutputReader) openSegment(offset int64) error {
+	if or.file != nil && or.fileStart == offset {
+		return nil
+	}
+
+	or.closeSegment()
+
+	file, err := os.Open(or.ob.segmentPath(offset))
+	if err != nil {
+		return err
+	}
+
+	index, err := os.Open(or.ob.indexPath(offset))
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	or.file = file
+	or.index = index
+	or.fileStart = offset
+
+	return nil
+}
+
+// readRecord reads the i-th record of the open segment's index file.
+func (or *OutputReader) readRecord(i int64) (outputRecord, error) {
+	buf := make([]byte, recordSize)
+	if _, err := or.index.ReadAt(buf, i*recordSize); err != nil {
+		return outputRecord{}, fmt.Errorf("read output index: %w", err)
+	}
+
+	return decodeRecord(buf), nil
+}
+
+// closeSegment closes the segment files the reader has open.
+func (or *OutputReader) closeSegment() {
+	if or.file != nil {
+		_ = or.file.Close()
+		_ = or.index.Close()
+		or.file = nil
+		or.index = nil
+	}
+}
+
+// Close releases the segment files the reader has open.
+func (or *OutputReader) Close() error {
+	or.closeSegment()
+	return nil
 }
@@ -3,7 +3,12 @@ package job
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -15,7 +20,7 @@ func TestOutputBuffer_Write(t *testing.T) {
 	t.Run("single", func(t *testing.T) {
 		t.Parallel()
 
-		ob := newOutputBuffer()
+		ob := newOutputBuffer(0)
 		data := []byte("hello world")
 		count, err := ob.Write(data)
 		if err != nil {
@@ -38,7 +43,7 @@ func TestOutputBuffer_Write(t *testing.T) {
 	t.Run("multiple", func(t *testing.T) {
 		t.Parallel()
 
-		ob := newOutputBuffer()
+		ob := newOutputBuffer(0)
 		_, _ = ob.Write([]byte("one"))
 		_, _ = ob.Write([]byte("two"))
 		_, _ = ob.Write([]byte("three"))
@@ -56,7 +61,7 @@ func TestOutputBuffer_Write(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		t.Parallel()
 
-		ob := newOutputBuffer()
+		ob := newOutputBuffer(0)
 		count, err := ob.Write(nil)
 		if count != 0 || err != nil {
 			t.Fatalf("Write nil (got=(%d, %v), want=(0, nil))", count, err)
@@ -79,7 +84,7 @@ func TestOutputBuffer_Write(t *testing.T) {
 	t.Run("after_close", func(t *testing.T) {
 		t.Parallel()
 
-		ob := newOutputBuffer()
+		ob := newOutputBuffer(0)
 		ob.Close()
 
 		count, err := ob.Write([]byte("hello"))
@@ -89,10 +94,50 @@ func TestOutputBuffer_Write(t *testing.T) {
 	})
 }
 
+func TestOutputBuffer_Limit(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		limit       int64
+		writes      []string
+		want        string
+		wantDropped int64
+	}{
+		{"under", 8, []string{"one", "two"}, "onetwo", 0},
+		{"exact", 6, []string{"one", "two"}, "onetwo", 0},
+		{"over", 5, []string{"one", "two"}, "netwo", 1},
+		{"write_over_limit", 4, []string{"hello world"}, "orld", 7},
+		{"many", 3, []string{"a", "b", "c", "d", "e"}, "cde", 2},
+		{"unlimited", 0, []string{"one", "two", "three"}, "onetwothree", 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ob := newOutputBuffer(tc.limit)
+			for _, data := range tc.writes {
+				_, _ = ob.Write([]byte(data))
+			}
+
+			ob.mu.RLock()
+			got := string(ob.mu.buf)
+			ob.mu.RUnlock()
+
+			if got != tc.want {
+				t.Fatalf("buffer data (got=%q, want=%q)", got, tc.want)
+			}
+
+			if dropped := ob.Dropped(); dropped != tc.wantDropped {
+				t.Fatalf("dropped (got=%d, want=%d)", dropped, tc.wantDropped)
+			}
+		})
+	}
+}
+
 func TestOutputBuffer_CloseIdempotent(t *testing.T) {
 	t.Parallel()
 
-	ob := newOutputBuffer()
+	ob := newOutputBuffer(0)
 	if err := ob.Close(); err != nil {
 		t.Fatalf("first Close (got=%v, want=nil)", err)
 	}
@@ -110,8 +155,8 @@ func TestOutputReader_Read(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		t.Cleanup(cancel)
 
-		ob := newOutputBuffer()
-		or := newOutputReader(ctx, ob)
+		ob := newOutputBuffer(0)
+		or := newOutputReader(ctx, ob, ReadOptions{})
 
 		// Read and then write the data to the done channel
 		done := make(chan []byte, 1)
@@ -145,8 +190,8 @@ func TestOutputReader_Read(t *testing.T) {
 	t.Run("eof", func(t *testing.T) {
 		t.Parallel()
 
-		ob := newOutputBuffer()
-		or := newOutputReader(context.Background(), ob)
+		ob := newOutputBuffer(0)
+		or := newOutputReader(context.Background(), ob, ReadOptions{})
 		ob.Close()
 
 		buf := make([]byte, 16)
@@ -162,11 +207,11 @@ func TestOutputReader_DrainsBeforeEOF(t *testing.T) {
 
 	data := []byte("hello world")
 	readSize := 4
-	ob := newOutputBuffer()
+	ob := newOutputBuffer(0)
 	_, _ = ob.Write(data)
 	ob.Close()
 
-	or := newOutputReader(context.Background(), ob)
+	or := newOutputReader(context.Background(), ob, ReadOptions{})
 	chunk := make([]byte, readSize)
 
 	count, err := or.Read(chunk)
@@ -189,11 +234,598 @@ func TestOutputReader_DrainsBeforeEOF(t *testing.T) {
 	}
 }
 
+func TestOutputReader_Truncated(t *testing.T) {
+	t.Parallel()
+
+	ob := newOutputBuffer(4)
+	or := newOutputReader(context.Background(), ob, ReadOptions{})
+	_, _ = ob.Write([]byte("hell"))
+
+	buf := make([]byte, 2)
+	count, err := or.Read(buf)
+	if err != nil || string(buf[:count]) != "he" {
+		t.Fatalf("first Read (got=(%q, %v), want=(%q, nil))", buf[:count], err, "he")
+	}
+
+	_, _ = ob.Write([]byte("o world"))
+	ob.Close()
+
+	// "llo w" fell out of the buffer before the reader got to it
+	_, err = or.Read(buf)
+	var truncErr *TruncatedError
+	if !errors.As(err, &truncErr) || truncErr.Bytes != 5 {
+		t.Fatalf("Read after drop (got=%v, want=truncated 5 bytes)", err)
+	}
+
+	got, err := io.ReadAll(or)
+	if err != nil {
+		t.Fatalf("ReadAll (got=%v, want=nil)", err)
+	}
+
+	if string(got) != "orld" {
+		t.Fatalf("data after truncation (got=%q, want=%q)", got, "orld")
+	}
+}
+
+// newTestSpillBuffer returns a spill buffer with a tiny window and segment size so tests cross both.
+func newTestSpillBuffer(t *testing.T, limit int64) *outputBuffer {
+	t.Helper()
+
+	ob, err := newSpillOutputBuffer(filepath.Join(t.TempDir(), "output"), limit)
+	if err != nil {
+		t.Fatalf("newSpillOutputBuffer (got=%v, want=nil)", err)
+	}
+
+	ob.window = 4
+	ob.segmentSize = 8
+	t.Cleanup(func() { ob.Close() })
+
+	return ob
+}
+
+func TestOutputBuffer_Spill(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name         string
+		limit        int64
+		writes       []string
+		want         string
+		wantDropped  int64
+		wantSegments int
+	}{
+		{"single_write", 0, []string{"abc"}, "abc", 0, 1},
+		{"one_segment", 0, []string{"hello", "wor"}, "hellowor", 0, 1},
+		{"many_segments", 0, []string{"hello", "wor", "ld", "and more"}, "helloworldand more", 0, 2},
+		{"limit_drops_segments", 12, []string{"hello", "wor", "ld", "and more"}, "ldand more", 8, 1},
+		{"limit_keeps_newest_segment", 2, []string{"hello world"}, "hello world", 0, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ob := newTestSpillBuffer(t, tc.limit)
+			for _, data := range tc.writes {
+				if _, err := ob.Write([]byte(data)); err != nil {
+					t.Fatalf("Write (got=%v, want=nil)", err)
+				}
+			}
+
+			ob.Close()
+
+			or := newOutputReader(context.Background(), ob, ReadOptions{})
+			defer or.Close()
+
+			got, err := io.ReadAll(or)
+			if tc.wantDropped > 0 {
+				var truncErr *TruncatedError
+				if !errors.As(err, &truncErr) || truncErr.Bytes != tc.wantDropped {
+					t.Fatalf("ReadAll (got=%v, want=truncated %d bytes)", err, tc.wantDropped)
+				}
+
+				// The reader continues from the oldest kept byte
+				got, err = io.ReadAll(or)
+			}
+
+			if err != nil {
+				t.Fatalf("ReadAll (got=%v, want=nil)", err)
+			}
+
+			if string(got) != tc.want {
+				t.Fatalf("data (got=%q, want=%q)", got, tc.want)
+			}
+
+			if dropped := ob.Dropped(); dropped != tc.wantDropped {
+				t.Fatalf("dropped (got=%d, want=%d)", dropped, tc.wantDropped)
+			}
+
+			for _, ext := range []string{"log", "idx"} {
+				files, err := filepath.Glob(filepath.Join(ob.dir, "*."+ext))
+				if err != nil {
+					t.Fatalf("Glob (got=%v, want=nil)", err)
+				}
+
+				if len(files) != tc.wantSegments {
+					t.Fatalf("%s files (got=%d, want=%d)", ext, len(files), tc.wantSegments)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputBuffer_SpillSmallLimit(t *testing.T) {
+	t.Parallel()
+
+	// A limit under the default segment size still bounds the segment files
+	ob, err := newSpillOutputBuffer(filepath.Join(t.TempDir(), "output"), 100)
+	if err != nil {
+		t.Fatalf("newSpillOutputBuffer (got=%v, want=nil)", err)
+	}
+
+	var data []byte
+	for i := range 50 {
+		write := bytes.Repeat([]byte{byte('a' + i%26)}, 10)
+		data = append(data, write...)
+		_, _ = ob.Write(write)
+	}
+
+	ob.Close()
+
+	if kept := int64(len(data)) - ob.Dropped(); kept > 100 {
+		t.Fatalf("kept bytes (got=%d, want<=100)", kept)
+	}
+
+	files, err := filepath.Glob(filepath.Join(ob.dir, "*.log"))
+	if err != nil {
+		t.Fatalf("Glob (got=%v, want=nil)", err)
+	}
+
+	var size int64
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Fatalf("Stat (got=%v, want=nil)", err)
+		}
+
+		size += info.Size()
+	}
+
+	if size > 100 {
+		t.Fatalf("segment files size (got=%d, want<=100)", size)
+	}
+
+	or := newOutputReader(context.Background(), ob, ReadOptions{Offset: ob.Dropped()})
+	defer or.Close()
+
+	got, err := io.ReadAll(or)
+	if err != nil {
+		t.Fatalf("ReadAll (got=%v, want=nil)", err)
+	}
+
+	if want := data[ob.Dropped():]; !bytes.Equal(got, want) {
+		t.Fatalf("data (got=%q, want=%q)", got, want)
+	}
+}
+
+func TestOutputBuffer_Remove(t *testing.T) {
+	t.Parallel()
+
+	ob := newTestSpillBuffer(t, 0)
+	_, _ = ob.Write([]byte("spilled output"))
+
+	if err := ob.Remove(); err != nil {
+		t.Fatalf("Remove (got=%v, want=nil)", err)
+	}
+
+	if _, err := os.Stat(ob.dir); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("stat output directory (got=%v, want=%v)", err, fs.ErrNotExist)
+	}
+}
+
+func TestOutputBuffer_SpillError(t *testing.T) {
+	t.Parallel()
+
+	ob := newTestSpillBuffer(t, 0)
+	_, _ = ob.Write([]byte("abc"))
+
+	// A read only index makes the next record fail to be written after its data was
+	index, err := os.Open(ob.indexPath(0))
+	if err != nil {
+		t.Fatalf("Open (got=%v, want=nil)", err)
+	}
+
+	ob.mu.index.Close()
+	ob.mu.index = index
+
+	// The failed write is kept in memory and the writer stays alive
+	if count, err := ob.Write([]byte("def")); err != nil || count != 3 {
+		t.Fatalf("Write (got=%d, %v, want=3, nil)", count, err)
+	}
+
+	info, err := os.Stat(ob.segmentPath(0))
+	if err != nil {
+		t.Fatalf("Stat (got=%v, want=nil)", err)
+	}
+
+	if info.Size() != 3 {
+		t.Fatalf("segment size (got=%d, want=3)", info.Size())
+	}
+
+	or := newOutputReader(context.Background(), ob, ReadOptions{NoFollow: true})
+	defer or.Close()
+
+	got, err := io.ReadAll(or)
+	if err != nil {
+		t.Fatalf("ReadAll (got=%v, want=nil)", err)
+	}
+
+	if string(got) != "abcdef" {
+		t.Fatalf("data (got=%q, want=%q)", got, "abcdef")
+	}
+
+	// Output that leaves memory without having been spilled is dropped
+	_, _ = ob.Write([]byte("gh"))
+	ob.Close()
+
+	or = newOutputReader(context.Background(), ob, ReadOptions{})
+	defer or.Close()
+
+	var truncErr *TruncatedError
+	if _, err := io.ReadAll(or); !errors.As(err, &truncErr) || truncErr.Bytes != 4 {
+		t.Fatalf("ReadAll (got=%v, want=truncated 4 bytes)", err)
+	}
+
+	got, err = io.ReadAll(or)
+	if err != nil {
+		t.Fatalf("ReadAll (got=%v, want=nil)", err)
+	}
+
+	if string(got) != "efgh" {
+		t.Fatalf("data (got=%q, want=%q)", got, "efgh")
+	}
+}
+
+func TestOutputReader_SegmentMissing(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		opts  ReadOptions
+		since bool
+	}{
+		{"offset", ReadOptions{}, false},
+		{"since", ReadOptions{}, true},
+		{"tail_bytes", ReadOptions{TailBytes: 12}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ob := newTestSpillBuffer(t, 0)
+			_, _ = ob.Write([]byte("hello world"))
+			mark := markTime()
+			_, _ = ob.Write([]byte("abcdefgh"))
+			ob.Close()
+
+			if tc.since {
+				tc.opts.Since = mark
+			}
+
+			// A segment missing without being removed over the limit is an error instead of a truncation
+			if err := os.Remove(ob.segmentPath(0)); err != nil {
+				t.Fatalf("Remove (got=%v, want=nil)", err)
+			}
+
+			or := newOutputReader(context.Background(), ob, tc.opts)
+			defer or.Close()
+
+			if _, err := io.ReadAll(or); !errors.Is(err, fs.ErrNotExist) {
+				t.Fatalf("ReadAll (got=%v, want=%v)", err, fs.ErrNotExist)
+			}
+		})
+	}
+}
+
+func TestOutputReader_SpillFollow(t *testing.T) {
+	t.Parallel()
+
+	ob := newTestSpillBuffer(t, 0)
+	or := newOutputReader(context.Background(), ob, ReadOptions{})
+	defer or.Close()
+
+	// Write 100 sequential bytes across many segments while reading
+	go func() {
+		defer ob.Close()
+
+		for i := range 100 {
+			_, _ = ob.Write([]byte{byte(i)})
+		}
+	}()
+
+	got, err := io.ReadAll(or)
+	if err != nil {
+		t.Fatalf("ReadAll (got=%v, want=nil)", err)
+	}
+
+	if len(got) != 100 {
+		t.Fatalf("byte count (got=%d, want=100)", len(got))
+	}
+
+	for i, b := range got {
+		if b != byte(i) {
+			t.Fatalf("byte %d (got=%d, want=%d)", i, b, i)
+		}
+	}
+}
+
+func TestOutputReader_Streams(t *testing.T) {
+	t.Parallel()
+
+	type write struct {
+		stream Stream
+		data   string
+	}
+
+	writes := []write{
+		{StreamStdout, "out1"},
+		{StreamStderr, "err1"},
+		{StreamStdout, "out2"},
+		{StreamStderr, "err2"},
+		{StreamStderr, "err3"},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		spill  bool
+		stream Stream
+		want   []write
+	}{
+		{"memory_all", false, StreamUnknown, writes},
+		{"memory_stdout", false, StreamStdout, []write{{StreamStdout, "out1"}, {StreamStdout, "out2"}}},
+		{"memory_stderr", false, StreamStderr, []write{{StreamStderr, "err1"}, {StreamStderr, "err2"}, {StreamStderr, "err3"}}},
+		{"spill_all", true, StreamUnknown, writes},
+		{"spill_stdout", true, StreamStdout, []write{{StreamStdout, "out1"}, {StreamStdout, "out2"}}},
+		{"spill_stderr", true, StreamStderr, []write{{StreamStderr, "err1"}, {StreamStderr, "err2"}, {StreamStderr, "err3"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ob := newOutputBuffer(0)
+			if tc.spill {
+				ob = newTestSpillBuffer(t, 0)
+			}
+
+			for _, w := range writes {
+				_, _ = ob.Stream(w.stream).Write([]byte(w.data))
+			}
+
+			ob.Close()
+
+			or := newOutputReader(context.Background(), ob, ReadOptions{Stream: tc.stream})
+			defer or.Close()
+
+			var got []write
+			buf := make([]byte, 16)
+			for {
+				chunk, err := or.ReadChunk(buf)
+				if err == io.EOF {
+					break
+				}
+
+				if err != nil {
+					t.Fatalf("ReadChunk (got=%v, want=nil)", err)
+				}
+
+				got = append(got, write{chunk.Stream, string(chunk.Data)})
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("chunks (got=%v, want=%v)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOutputReader_Since(t *testing.T) {
+	t.Parallel()
+
+	writes := []string{"out1", "out2", "out3", "out4", "out5", "out6"}
+
+	for _, tc := range []struct {
+		name  string
+		spill bool
+		// mark is the number of writes made before the since time
+		mark int
+		want string
+	}{
+		{"memory_start", false, 0, "out1out2out3out4out5out6"},
+		{"memory_middle", false, 3, "out4out5out6"},
+		{"memory_end", false, 6, ""},
+		{"spill_start", true, 0, "out1out2out3out4out5out6"},
+		{"spill_segment_start", true, 2, "out3out4out5out6"},
+		{"spill_segment_middle", true, 3, "out4out5out6"},
+		{"spill_memory", true, 5, "out6"},
+		{"spill_end", true, 6, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ob := newOutputBuffer(0)
+			if tc.spill {
+				ob = newTestSpillBuffer(t, 0)
+			}
+
+			var since time.Time
+			for i, data := range writes {
+				if i == tc.mark {
+					since = markTime()
+				}
+
+				_, _ = ob.Write([]byte(data))
+			}
+
+			if tc.mark == len(writes) {
+				since = markTime()
+			}
+
+			ob.Close()
+
+			or := newOutputReader(context.Background(), ob, ReadOptions{Since: since})
+			defer or.Close()
+
+			got, err := io.ReadAll(or)
+			if err != nil {
+				t.Fatalf("ReadAll (got=%v, want=nil)", err)
+			}
+
+			if string(got) != tc.want {
+				t.Fatalf("output (got=%q, want=%q)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOutputReader_Start(t *testing.T) {
+	t.Parallel()
+
+	type write struct {
+		stream Stream
+		data   string
+	}
+
+	writes := []write{
+		{StreamStdout, "one\ntw"},
+		{StreamStderr, "err1\n"},
+		{StreamStdout, "o\nthree\n"},
+		{StreamStderr, "err2\n"},
+		{StreamStdout, "four"},
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts ReadOptions
+		want string
+	}{
+		{"offset", ReadOptions{Offset: 4}, "twerr1\no\nthree\nerr2\nfour"},
+		{"offset_end", ReadOptions{Offset: 29}, ""},
+		{"tail_bytes", ReadOptions{TailBytes: 10}, "\nerr2\nfour"},
+		{"tail_bytes_all", ReadOptions{TailBytes: 100}, "one\ntwerr1\no\nthree\nerr2\nfour"},
+		{"tail_lines", ReadOptions{TailLines: 2}, "err2\nfour"},
+		{"tail_lines_all", ReadOptions{TailLines: 10}, "one\ntwerr1\no\nthree\nerr2\nfour"},
+		{"tail_lines_stdout", ReadOptions{Stream: StreamStdout, TailLines: 3}, "two\nthree\nfour"},
+		{"tail_lines_stderr", ReadOptions{Stream: StreamStderr, TailLines: 1}, "err2\n"},
+		{"tail_bytes_stdout", ReadOptions{Stream: StreamStdout, TailBytes: 7}, "ee\nfour"},
+	} {
+		for _, spill := range []bool{false, true} {
+			name := "memory_" + tc.name
+			if spill {
+				name = "spill_" + tc.name
+			}
+
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				ob := newOutputBuffer(0)
+				if spill {
+					ob = newTestSpillBuffer(t, 0)
+				}
+
+				for _, w := range writes {
+					_, _ = ob.Stream(w.stream).Write([]byte(w.data))
+				}
+
+				ob.Close()
+
+				or := newOutputReader(context.Background(), ob, tc.opts)
+				defer or.Close()
+
+				got, err := io.ReadAll(or)
+				if err != nil {
+					t.Fatalf("ReadAll (got=%v, want=nil)", err)
+				}
+
+				if string(got) != tc.want {
+					t.Fatalf("output (got=%q, want=%q)", got, tc.want)
+				}
+			})
+		}
+	}
+}
+
+func TestOutputReader_ChunkOffset(t *testing.T) {
+	t.Parallel()
+
+	ob := newTestSpillBuffer(t, 0)
+	for _, data := range []string{"abc", "defgh", "ij"} {
+		_, _ = ob.Write([]byte(data))
+	}
+
+	ob.Close()
+
+	or := newOutputReader(context.Background(), ob, ReadOptions{Offset: 1})
+	defer or.Close()
+
+	var got []int64
+	buf := make([]byte, 16)
+	for {
+		chunk, err := or.ReadChunk(buf)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("ReadChunk (got=%v, want=nil)", err)
+		}
+
+		got = append(got, chunk.Offset)
+	}
+
+	if want := []int64{1, 3, 8}; !slices.Equal(got, want) {
+		t.Fatalf("chunk offsets (got=%v, want=%v)", got, want)
+	}
+
+	if or.Offset() != 10 {
+		t.Fatalf("reader offset (got=%d, want=10)", or.Offset())
+	}
+}
+
+func TestOutputReader_NoFollow(t *testing.T) {
+	t.Parallel()
+
+	ob := newOutputBuffer(0)
+	_, _ = ob.Write([]byte("before"))
+
+	or := newOutputReader(context.Background(), ob, ReadOptions{NoFollow: true})
+	buf := make([]byte, 16)
+	chunk, err := or.ReadChunk(buf)
+	if err != nil {
+		t.Fatalf("ReadChunk (got=%v, want=nil)", err)
+	}
+
+	// Output written after reading started isn't read
+	_, _ = ob.Write([]byte("after"))
+
+	if string(chunk.Data) != "before" {
+		t.Fatalf("data (got=%q, want=%q)", chunk.Data, "before")
+	}
+
+	if _, err := or.ReadChunk(buf); err != io.EOF {
+		t.Fatalf("ReadChunk (got=%v, want=%v)", err, io.EOF)
+	}
+}
+
+// markTime returns a time strictly after the writes made before it and before the writes made after it.
+func markTime() time.Time {
+	time.Sleep(time.Millisecond)
+	mark := time.Now()
+	time.Sleep(time.Millisecond)
+
+	return mark
+}
+
 func TestOutputReader_ZeroLengthBuffer(t *testing.T) {
 	t.Parallel()
 
-	ob := newOutputBuffer()
-	or := newOutputReader(context.Background(), ob)
+	ob := newOutputBuffer(0)
+	or := newOutputReader(context.Background(), ob, ReadOptions{})
 	_, _ = ob.Write([]byte("hello world"))
 
 	count, err := or.Read([]byte{})
@@ -209,8 +841,8 @@ func TestOutputReader_ContextCancel(t *testing.T) {
 		t.Parallel()
 		ctx, cancel := context.WithCancel(context.Background())
 
-		ob := newOutputBuffer()
-		or := newOutputReader(ctx, ob)
+		ob := newOutputBuffer(0)
+		or := newOutputReader(ctx, ob, ReadOptions{})
 
 		cancel()
 
@@ -225,8 +857,8 @@ func TestOutputReader_ContextCancel(t *testing.T) {
 		t.Parallel()
 		ctx, cancel := context.WithCancel(context.Background())
 
-		ob := newOutputBuffer()
-		or := newOutputReader(ctx, ob)
+		ob := newOutputBuffer(0)
+		or := newOutputReader(ctx, ob, ReadOptions{})
 
 		// Read and then write the error to the done channel
 		done := make(chan error, 1)
@@ -254,14 +886,14 @@ func TestOutputReader_MultipleReaders(t *testing.T) {
 	t.Parallel()
 
 	data := []byte("hello world")
-	ob := newOutputBuffer()
+	ob := newOutputBuffer(0)
 	_, _ = ob.Write(data)
 	ob.Close()
 
 	var wg sync.WaitGroup
 	for range 3 {
 		wg.Go(func() {
-			or := newOutputReader(context.Background(), ob)
+			or := newOutputReader(context.Background(), ob, ReadOptions{})
 			got, err := io.ReadAll(or)
 			if err != nil {
 				t.Errorf("ReadAll (got=%v, want=nil)", err)
@@ -279,8 +911,8 @@ func TestOutputReader_MultipleReaders(t *testing.T) {
 func TestOutputReader_ConcurrentWriteAndRead(t *testing.T) {
 	t.Parallel()
 
-	ob := newOutputBuffer()
-	or := newOutputReader(context.Background(), ob)
+	ob := newOutputBuffer(0)
+	or := newOutputReader(context.Background(), ob, ReadOptions{})
 
 	// Write 100 sequential bytes
 	go func() {
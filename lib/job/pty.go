@@ -0,0 +1,64 @@
+package job
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// defaultRows is the terminal height until the first resize.
+	defaultRows = 24
+	// defaultCols is the terminal width until the first resize.
+	defaultCols = 80
+	// eofChar is the terminal EOF character (^D).
+	eofChar = 0x04
+)
+
+// openPTY opens a pseudo-terminal and returns its master and slave ends.
+func openPTY() (master, slave *os.File, err error) {
+	fd, err := unix.Open("/dev/ptmx", unix.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open ptmx: %w", err)
+	}
+
+	master = os.NewFile(uintptr(fd), "/dev/ptmx")
+
+	// defer closing the master on error
+	defer func() {
+		if err != nil {
+			master.Close()
+		}
+	}()
+
+	// Unlock the slave so it can be opened
+	if err = unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		return nil, nil, fmt.Errorf("unlock pty: %w", err)
+	}
+
+	num, err := unix.IoctlGetUint32(fd, unix.TIOCGPTN)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get pty number: %w", err)
+	}
+
+	if err = resizePTY(master, defaultRows, defaultCols); err != nil {
+		return nil, nil, err
+	}
+
+	slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", num), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open pty slave: %w", err)
+	}
+
+	return master, slave, nil
+}
+
+// resizePTY sets the window size of a pseudo-terminal.
+func resizePTY(master *os.File, rows, cols uint16) error {
+	if err := unix.IoctlSetWinsize(int(master.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Row: rows, Col: cols}); err != nil {
+		return fmt.Errorf("set window size: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package job
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestOpenPTY(t *testing.T) {
+	t.Parallel()
+
+	master, slave, err := openPTY()
+	if err != nil {
+		t.Fatalf("openPTY (got=%v, want=nil)", err)
+	}
+
+	defer master.Close()
+	defer slave.Close()
+
+	if _, err := slave.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("slave Write (got=%v, want=nil)", err)
+	}
+
+	// The terminal translates \n to \r\n on output
+	buf := make([]byte, 16)
+	count, err := master.Read(buf)
+	if err != nil {
+		t.Fatalf("master Read (got=%v, want=nil)", err)
+	}
+
+	if want := []byte("hello\r\n"); !bytes.Equal(buf[:count], want) {
+		t.Fatalf("master data (got=%q, want=%q)", buf[:count], want)
+	}
+}
+
+func TestResizePTY(t *testing.T) {
+	t.Parallel()
+
+	master, slave, err := openPTY()
+	if err != nil {
+		t.Fatalf("openPTY (got=%v, want=nil)", err)
+	}
+
+	defer master.Close()
+	defer slave.Close()
+
+	ws, err := unix.IoctlGetWinsize(int(slave.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		t.Fatalf("get window size (got=%v, want=nil)", err)
+	}
+
+	if ws.Row != defaultRows || ws.Col != defaultCols {
+		t.Fatalf("default size (got=%dx%d, want=%dx%d)", ws.Row, ws.Col, defaultRows, defaultCols)
+	}
+
+	if err := resizePTY(master, 50, 120); err != nil {
+		t.Fatalf("resizePTY (got=%v, want=nil)", err)
+	}
+
+	ws, err = unix.IoctlGetWinsize(int(slave.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		t.Fatalf("get window size (got=%v, want=nil)", err)
+	}
+
+	if ws.Row != 50 || ws.Col != 120 {
+		t.Fatalf("resized size (got=%dx%d, want=50x120)", ws.Row, ws.Col)
+	}
+}
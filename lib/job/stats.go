@@ -0,0 +1,183 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stats is a sample of a job's resource usage read from its cgroup.
+type Stats struct {
+	// Time is when the sample was taken.
+	Time time.Time
+	// Final is true for the last sample taken when the process exited, right before the cgroup is removed.
+	Final  bool
+	CPU    CPUStats
+	Memory MemoryStats
+	// IO has an entry for each block device the job has used.
+	IO   []IOStats
+	PIDs PIDsStats
+}
+
+// CPUStats holds usage from cpu.stat in microseconds.
+type CPUStats struct {
+	UsageUsec     uint64
+	UserUsec      uint64
+	SystemUsec    uint64
+	NrPeriods     uint64
+	NrThrottled   uint64
+	ThrottledUsec uint64
+}
+
+// MemoryStats holds usage from memory.current, memory.peak and memory.stat in bytes and event counts from
+// memory.events.
+type MemoryStats struct {
+	Current uint64
+	Peak    uint64
+	Anon    uint64
+	File    uint64
+	Kernel  uint64
+	Shmem   uint64
+	Sock    uint64
+	// PgFault and PgMajFault are page fault counts.
+	PgFault    uint64
+	PgMajFault uint64
+	// OOM is how many times memory.max was hit and OOMKill how many processes the OOM killer killed.
+	OOM     uint64
+	OOMKill uint64
+}
+
+// IOStats holds usage from io.stat for a block device.
+type IOStats struct {
+	// Device is the block device MAJ:MIN.
+	Device string
+	RBytes uint64
+	WBytes uint64
+	RIOs   uint64
+	WIOs   uint64
+	DBytes uint64
+	DIOs   uint64
+}
+
+// PIDsStats holds usage from pids.current.
+type PIDsStats struct {
+	Current uint64
+}
+
+// clone returns a copy of the stats that does not share the IO slice.
+func (s Stats) clone() Stats {
+	s.IO = slices.Clone(s.IO)
+	return s
+}
+
+// readStats samples a job's resource usage from its cgroup.
+func readStats(id string) (Stats, error) {
+	stats := Stats{Time: time.Now()}
+
+	cpu, err := readCgroupStats(id, "cpu.stat")
+	if err != nil {
+		return Stats{}, fmt.Errorf("read cpu.stat: %w", err)
+	}
+
+	stats.CPU = CPUStats{
+		UsageUsec:     cpu["usage_usec"],
+		UserUsec:      cpu["user_usec"],
+		SystemUsec:    cpu["system_usec"],
+		NrPeriods:     cpu["nr_periods"],
+		NrThrottled:   cpu["nr_throttled"],
+		ThrottledUsec: cpu["throttled_usec"],
+	}
+
+	if stats.Memory.Current, err = readCgroupValue(id, "memory.current"); err != nil {
+		return Stats{}, fmt.Errorf("read memory.current: %w", err)
+	}
+
+	// memory.peak is only available on newer kernels
+	if stats.Memory.Peak, err = readCgroupValue(id, "memory.peak"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Stats{}, fmt.Errorf("read memory.peak: %w", err)
+	}
+
+	memory, err := readCgroupStats(id, "memory.stat")
+	if err != nil {
+		return Stats{}, fmt.Errorf("read memory.stat: %w", err)
+	}
+
+	stats.Memory.Anon = memory["anon"]
+	stats.Memory.File = memory["file"]
+	stats.Memory.Kernel = memory["kernel"]
+	stats.Memory.Shmem = memory["shmem"]
+	stats.Memory.Sock = memory["sock"]
+	stats.Memory.PgFault = memory["pgfault"]
+	stats.Memory.PgMajFault = memory["pgmajfault"]
+
+	events, err := readCgroupStats(id, "memory.events")
+	if err != nil {
+		return Stats{}, fmt.Errorf("read memory.events: %w", err)
+	}
+
+	stats.Memory.OOM = events["oom"]
+	stats.Memory.OOMKill = events["oom_kill"]
+
+	data, err := os.ReadFile(filepath.Join(getCgroupDir(id), "io.stat"))
+	if err != nil {
+		return Stats{}, fmt.Errorf("read io.stat: %w", err)
+	}
+
+	if stats.IO, err = parseIOStat(string(data)); err != nil {
+		return Stats{}, err
+	}
+
+	if stats.PIDs.Current, err = readCgroupValue(id, "pids.current"); err != nil {
+		return Stats{}, fmt.Errorf("read pids.current: %w", err)
+	}
+
+	return stats, nil
+}
+
+// parseIOStat parses the "<MAJ:MIN> <key>=<value>..." lines of io.stat.
+func parseIOStat(data string) ([]IOStats, error) {
+	var stats []IOStats
+	for line := range strings.Lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		device := IOStats{Device: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+
+			num, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse io stat (device=%s, key=%s): %w", device.Device, key, err)
+			}
+
+			switch key {
+			case "rbytes":
+				device.RBytes = num
+			case "wbytes":
+				device.WBytes = num
+			case "rios":
+				device.RIOs = num
+			case "wios":
+				device.WIOs = num
+			case "dbytes":
+				device.DBytes = num
+			case "dios":
+				device.DIOs = num
+			}
+		}
+
+		stats = append(stats, device)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,44 @@
+package job
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseIOStat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := parseIOStat("8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n259:0 rbytes=1 wbytes=0 rios=1 wios=0 dbytes=0 dios=0\n")
+		if err != nil {
+			t.Fatalf("parseIOStat (got=%v, want=nil)", err)
+		}
+
+		want := []IOStats{
+			{Device: "8:0", RBytes: 4096, WBytes: 8192, RIOs: 1, WIOs: 2},
+			{Device: "259:0", RBytes: 1, RIOs: 1},
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("stats (got=%+v, want=%+v)", got, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := parseIOStat("")
+		if err != nil || len(got) != 0 {
+			t.Fatalf("parseIOStat (got=(%v, %v), want=(empty, nil))", got, err)
+		}
+	})
+
+	t.Run("invalid_value", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := parseIOStat("8:0 rbytes=abc\n"); err == nil {
+			t.Fatal("parseIOStat (got=nil, want=error)")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
+)
+
+const (
+	// minimalPath is the PATH of jobs started from a minimal environment.
+	minimalPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+	// defaultTerm is the TERM of terminal jobs when the base environment does not set one.
+	defaultTerm = "xterm-256color"
+)
+
+// buildEnv builds a job's environment as sorted KEY=VALUE pairs from a base environment and the requested variables.
+func buildEnv(base taskerpb.EnvBase, vars map[string]string, tty bool) ([]string, error) {
+	env := make(map[string]string)
+
+	switch base {
+	case taskerpb.EnvBase_ENV_BASE_UNSPECIFIED:
+		for _, pair := range os.Environ() {
+			key, value, _ := strings.Cut(pair, "=")
+			env[key] = value
+		}
+	case taskerpb.EnvBase_ENV_BASE_MINIMAL:
+		env["PATH"] = minimalPath
+	case taskerpb.EnvBase_ENV_BASE_EMPTY:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown env base (%v)", base)
+	}
+
+	// Terminal programs need TERM but an empty environment stays empty
+	if _, exists := env["TERM"]; tty && !exists && base != taskerpb.EnvBase_ENV_BASE_EMPTY {
+		env["TERM"] = defaultTerm
+	}
+
+	for key, value := range vars {
+		if key == "" || strings.ContainsAny(key, "=\x00") || strings.ContainsRune(value, 0) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid env variable (key=%q)", key)
+		}
+
+		env[key] = value
+	}
+
+	pairs := make([]string, 0, len(env))
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		pairs = append(pairs, key+"="+env[key])
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
+)
+
+func TestBuildEnv(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		base taskerpb.EnvBase
+		vars map[string]string
+		tty  bool
+		want []string
+	}{
+		{"empty", taskerpb.EnvBase_ENV_BASE_EMPTY, nil, false, []string{}},
+		{"empty_tty", taskerpb.EnvBase_ENV_BASE_EMPTY, nil, true, []string{}},
+		{
+			"empty_vars",
+			taskerpb.EnvBase_ENV_BASE_EMPTY,
+			map[string]string{"B": "2", "A": "1"},
+			false,
+			[]string{"A=1", "B=2"},
+		},
+		{"minimal", taskerpb.EnvBase_ENV_BASE_MINIMAL, nil, false, []string{"PATH=" + minimalPath}},
+		{
+			"minimal_tty",
+			taskerpb.EnvBase_ENV_BASE_MINIMAL,
+			nil,
+			true,
+			[]string{"PATH=" + minimalPath, "TERM=" + defaultTerm},
+		},
+		{
+			"minimal_override",
+			taskerpb.EnvBase_ENV_BASE_MINIMAL,
+			map[string]string{"PATH": "/opt/bin", "TERM": "dumb"},
+			true,
+			[]string{"PATH=/opt/bin", "TERM=dumb"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := buildEnv(tc.base, tc.vars, tc.tty)
+			if err != nil {
+				t.Fatalf("buildEnv (got=%v, want=nil)", err)
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("env (got=%v, want=%v)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildEnv_Inherit(t *testing.T) {
+	t.Parallel()
+
+	got, err := buildEnv(taskerpb.EnvBase_ENV_BASE_UNSPECIFIED, map[string]string{"TASKER_TEST": "1"}, false)
+	if err != nil {
+		t.Fatalf("buildEnv (got=%v, want=nil)", err)
+	}
+
+	if !slices.Contains(got, "TASKER_TEST=1") {
+		t.Fatalf("env missing requested variable (got=%v)", got)
+	}
+
+	if path, exists := os.LookupEnv("PATH"); exists && !slices.Contains(got, "PATH="+path) {
+		t.Fatalf("env missing inherited PATH (got=%v)", got)
+	}
+}
+
+func TestBuildEnv_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		base taskerpb.EnvBase
+		vars map[string]string
+	}{
+		{"unknown_base", taskerpb.EnvBase(99), nil},
+		{"empty_key", taskerpb.EnvBase_ENV_BASE_EMPTY, map[string]string{"": "1"}},
+		{"key_with_equals", taskerpb.EnvBase_ENV_BASE_EMPTY, map[string]string{"A=B": "1"}},
+		{"value_with_nul", taskerpb.EnvBase_ENV_BASE_EMPTY, map[string]string{"A": "1\x00"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := buildEnv(tc.base, tc.vars, false)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Fatalf("code (got=%v, want=%v)", got, codes.InvalidArgument)
+			}
+		})
+	}
+}
@@ -2,13 +2,23 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
 	"github.com/wolves-fc/tasker/lib/job"
@@ -16,6 +26,30 @@ import (
 	"github.com/wolves-fc/tasker/lib/tls"
 )
 
+const (
+	// defaultPageSize is the number of jobs returned by ListJobs when no page size is given.
+	defaultPageSize = 100
+	// maxPageSize is the max number of jobs returned by ListJobs.
+	maxPageSize = 1000
+	// defaultStopGrace is how long a stop waits after the stop signal before the cgroup is killed.
+	defaultStopGrace = 2 * time.Second
+	// maxSignal is the highest signal number (SIGRTMAX).
+	maxSignal = 64
+	// defaultStatsInterval is the time between WatchJobStats samples when no interval is given.
+	defaultStatsInterval = time.Second
+	// minStatsInterval is the shortest time between WatchJobStats samples.
+	minStatsInterval = 100 * time.Millisecond
+	// minCPU is the smallest CPU limit in cores (the kernel rejects cpu.max quotas under 1ms per 100ms period).
+	minCPU = 0.01
+	// maxCPU is the largest CPU limit or burst in cores (the kernel rejects cpu.max quotas over 2^44-1us per 100ms
+	// period).
+	maxCPU = (1<<44 - 1) / 100000
+	// maxCPUWeight is the highest cpu.weight the kernel accepts.
+	maxCPUWeight = 10000
+	// maxIOWeight is the highest io.weight the kernel accepts.
+	maxIOWeight = 10000
+)
+
 func (s *Server) StartJob(ctx context.Context, req *taskerpb.StartJobRequest) (*taskerpb.StartJobResponse, error) {
 	if req.Command == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "command is required")
@@ -26,26 +60,65 @@ func (s *Server) StartJob(ctx context.Context, req *taskerpb.StartJobRequest) (*
 		return nil, err
 	}
 
-	limits := job.Limits{}
+	// Jobs never run as the server's user so an identity without an account cannot start jobs
+	account, exists := s.cfg.Users.Lookup(identity)
+	if !exists {
+		return nil, status.Errorf(codes.PermissionDenied, "no run-as account for identity (name=%s, role=%s)", identity.Name, identity.Role)
+	}
 
-	if req.Limits != nil {
-		limits.CPU = req.Limits.Cpu
-		limits.Memory = req.Limits.Memory
+	limits, err := s.convertLimits(req.Limits)
+	if err != nil {
+		return nil, err
+	}
 
-		if req.Limits.Io != nil {
-			if req.Limits.Io.Device == "" {
-				return nil, status.Error(codes.InvalidArgument, "device is required when IO limits are set")
-			}
+	if limits.PIDs == nil {
+		pids := s.cfg.PIDs
+		limits.PIDs = &pids
+	}
 
-			limits.IO = &job.IOLimits{
-				Device: req.Limits.Io.Device,
-				Read:   req.Limits.Io.Read,
-				Write:  req.Limits.Io.Write,
-			}
+	env, err := buildEnv(req.EnvBase, req.Env, req.Tty)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Workdir != "" && !filepath.IsAbs(req.Workdir) {
+		return nil, status.Errorf(codes.InvalidArgument, "workdir must be an absolute path (workdir=%s)", req.Workdir)
+	}
+
+	var timeout time.Duration
+	if req.Timeout != nil {
+		if *req.Timeout == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "timeout must be at least 1 second")
+		}
+
+		timeout = time.Duration(*req.Timeout) * time.Second
+	}
+
+	var stopSignal unix.Signal
+	if req.StopSignal != nil {
+		if stopSignal, err = parseSignal(*req.StopSignal); err != nil {
+			return nil, err
 		}
 	}
 
-	j, err := job.New(req.Command, req.Args, identity.Name, limits)
+	stopGrace := defaultStopGrace
+	if req.StopGrace != nil {
+		stopGrace = time.Duration(*req.StopGrace) * time.Second
+	}
+
+	j, err := job.New(req.Command, req.Args, identity.Name, job.Options{
+		Limits:      limits,
+		Stdin:       req.Stdin,
+		TTY:         req.Tty,
+		Env:         env,
+		Dir:         req.Workdir,
+		Credential:  account.credential(),
+		Timeout:     timeout,
+		StopSignal:  stopSignal,
+		StopGrace:   stopGrace,
+		OutputLimit: s.cfg.OutputLimit,
+		OutputDir:   s.outputDir(),
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "start failed: %v", err)
 	}
@@ -65,23 +138,28 @@ func (s *Server) StopJob(ctx context.Context, req *taskerpb.StopJobRequest) (*ta
 		return nil, err
 	}
 
-	s.mu.RLock()
-	j, exists := s.mu.jobs[req.Id]
-	s.mu.RUnlock()
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return nil, err
+	}
 
-	if !exists {
-		return nil, status.Errorf(codes.NotFound, "job not found (id=%s)", req.Id)
+	sig := j.StopSignal()
+	if req.Signal != nil {
+		if sig, err = parseSignal(*req.Signal); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := checkJobAccess(identity, j.Owner()); err != nil {
-		return nil, err
+	grace := j.StopGrace()
+	if req.Grace != nil {
+		grace = time.Duration(*req.Grace) * time.Second
 	}
 
-	// Give the job 2 seconds to gracefully stop
-	stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	// Give the job its grace period to gracefully stop
+	stopCtx, cancel := context.WithTimeout(context.Background(), grace)
 	defer cancel()
 
-	if err := j.Stop(stopCtx); err != nil {
+	if err := j.Stop(stopCtx, sig, identity.Name); err != nil {
 		fmt.Printf("job force killed (id=%s, owner=%s): %v\n", j.ID(), identity.Name, err)
 	} else {
 		fmt.Printf("job stopped (id=%s, owner=%s)\n", j.ID(), identity.Name)
@@ -96,19 +174,51 @@ func (s *Server) GetJob(ctx context.Context, req *taskerpb.GetJobRequest) (*task
 		return nil, err
 	}
 
-	s.mu.RLock()
-	j, exists := s.mu.jobs[req.Id]
-	s.mu.RUnlock()
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return nil, err
+	}
 
-	if !exists {
-		return nil, status.Errorf(codes.NotFound, "job not found (id=%s)", req.Id)
+	return &taskerpb.GetJobResponse{Job: convertJob(j)}, nil
+}
+
+func (s *Server) ListJobs(ctx context.Context, req *taskerpb.ListJobsRequest) (*taskerpb.ListJobsResponse, error) {
+	identity, err := rpc.IdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := checkJobAccess(identity, j.Owner()); err != nil {
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		return nil, status.Errorf(codes.InvalidArgument, "page size must be at most %d", maxPageSize)
+	}
+
+	pageToken, err := parsePageToken(req.PageToken)
+	if err != nil {
 		return nil, err
 	}
 
-	return &taskerpb.GetJobResponse{Job: convertJob(j)}, nil
+	s.mu.RLock()
+	jobs := make([]*job.Job, 0, len(s.mu.jobs))
+	for _, j := range s.mu.jobs {
+		if checkJobAccess(identity, j.Owner()) == nil {
+			jobs = append(jobs, j)
+		}
+	}
+	s.mu.RUnlock()
+
+	// Only the jobs on the page are converted since converting a running job reads its cgroup
+	page, next := pageJobs(req, jobs, pageToken, pageSize)
+
+	resp := &taskerpb.ListJobsResponse{NextPageToken: next}
+	for _, j := range page {
+		resp.Jobs = append(resp.Jobs, convertJob(j))
+	}
+
+	return resp, nil
 }
 
 func (s *Server) AttachJob(req *taskerpb.AttachJobRequest, stream grpc.ServerStreamingServer[taskerpb.AttachJobResponse]) error {
@@ -117,28 +227,45 @@ func (s *Server) AttachJob(req *taskerpb.AttachJobRequest, stream grpc.ServerStr
 		return err
 	}
 
-	s.mu.RLock()
-	j, exists := s.mu.jobs[req.Id]
-	s.mu.RUnlock()
-
-	if !exists {
-		return status.Errorf(codes.NotFound, "job not found (id=%s)", req.Id)
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return err
 	}
 
-	if err := checkJobAccess(identity, j.Owner()); err != nil {
+	opts, err := parseReadOptions(req)
+	if err != nil {
 		return err
 	}
 
 	// Read in 4KB chunks
-	r := j.NewReader(stream.Context())
+	r := j.NewReader(stream.Context(), opts)
+	defer r.Close()
+
 	buf := make([]byte, 4096)
 
 	for {
-		count, err := r.Read(buf)
-		if count > 0 {
-			if sendErr := stream.Send(&taskerpb.AttachJobResponse{Data: buf[:count]}); sendErr != nil {
+		chunk, err := r.ReadChunk(buf)
+		if len(chunk.Data) > 0 {
+			resp := &taskerpb.AttachJobResponse{
+				Data:   chunk.Data,
+				Stream: convertOutputStream(chunk.Stream),
+				Time:   timestamppb.New(chunk.Time),
+				Offset: uint64(chunk.Offset),
+			}
+			if sendErr := stream.Send(resp); sendErr != nil {
+				return sendErr
+			}
+		}
+
+		// The reader fell behind the output limit and skipped ahead
+		var truncErr *job.TruncatedError
+		if errors.As(err, &truncErr) {
+			resp := &taskerpb.AttachJobResponse{Truncated: uint64(truncErr.Bytes), Offset: uint64(r.Offset())}
+			if sendErr := stream.Send(resp); sendErr != nil {
 				return sendErr
 			}
+
+			continue
 		}
 
 		if err != nil {
@@ -150,6 +277,281 @@ func (s *Server) AttachJob(req *taskerpb.AttachJobRequest, stream grpc.ServerStr
 	}
 }
 
+func (s *Server) SendJobInput(
+	stream grpc.ClientStreamingServer[taskerpb.SendJobInputRequest, taskerpb.SendJobInputResponse],
+) error {
+	identity, err := rpc.IdentityFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	// The first message identifies the job
+	req, err := stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return status.Error(codes.InvalidArgument, "job id is required")
+		}
+		return err
+	}
+
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return err
+	}
+
+	var written uint64
+	for {
+		switch input := req.Input.(type) {
+		case *taskerpb.SendJobInputRequest_Data:
+			count, err := j.WriteInput(input.Data)
+			written += uint64(count)
+			if err != nil {
+				return status.Errorf(codes.FailedPrecondition, "write stdin (id=%s): %v", j.ID(), err)
+			}
+		case *taskerpb.SendJobInputRequest_Close:
+			if input.Close {
+				if err := j.CloseInput(); err != nil {
+					return status.Errorf(codes.Internal, "close stdin (id=%s): %v", j.ID(), err)
+				}
+			}
+		case *taskerpb.SendJobInputRequest_Resize:
+			if input.Resize.Rows > math.MaxUint16 || input.Resize.Cols > math.MaxUint16 {
+				return status.Error(codes.InvalidArgument, "window size is too large")
+			}
+
+			if err := j.Resize(uint16(input.Resize.Rows), uint16(input.Resize.Cols)); err != nil {
+				return status.Errorf(codes.FailedPrecondition, "resize terminal (id=%s): %v", j.ID(), err)
+			}
+		}
+
+		req, err = stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return stream.SendAndClose(&taskerpb.SendJobInputResponse{Written: written})
+			}
+			return err
+		}
+	}
+}
+
+func (s *Server) SignalJob(ctx context.Context, req *taskerpb.SignalJobRequest) (*taskerpb.SignalJobResponse, error) {
+	identity, err := rpc.IdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := parseSignal(req.Signal)
+	if err != nil {
+		return nil, err
+	}
+
+	signaled, err := j.Signal(sig, req.All)
+	if err != nil {
+		if errors.Is(err, job.ErrNotRunning) {
+			return nil, status.Errorf(codes.FailedPrecondition, "job is not running (id=%s)", j.ID())
+		}
+
+		return nil, status.Errorf(codes.Internal, "signal failed (id=%s): %v", j.ID(), err)
+	}
+
+	fmt.Printf("job signaled (id=%s, owner=%s, signal=%s)\n", j.ID(), identity.Name, signalName(sig))
+
+	return &taskerpb.SignalJobResponse{Job: convertJob(j), Signaled: uint32(signaled)}, nil
+}
+
+func (s *Server) PauseJob(ctx context.Context, req *taskerpb.PauseJobRequest) (*taskerpb.PauseJobResponse, error) {
+	identity, err := rpc.IdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Give the cgroup 2 seconds to freeze
+	pauseCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	if err := j.Pause(pauseCtx, identity.Name); err != nil {
+		if errors.Is(err, job.ErrNotRunning) {
+			return nil, status.Errorf(codes.FailedPrecondition, "job is not running (id=%s)", j.ID())
+		}
+
+		return nil, status.Errorf(codes.Internal, "pause failed (id=%s): %v", j.ID(), err)
+	}
+
+	fmt.Printf("job paused (id=%s, owner=%s)\n", j.ID(), identity.Name)
+
+	return &taskerpb.PauseJobResponse{Job: convertJob(j)}, nil
+}
+
+func (s *Server) ResumeJob(ctx context.Context, req *taskerpb.ResumeJobRequest) (*taskerpb.ResumeJobResponse, error) {
+	identity, err := rpc.IdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := j.Resume(identity.Name); err != nil {
+		if errors.Is(err, job.ErrNotPaused) {
+			return nil, status.Errorf(codes.FailedPrecondition, "job is not paused (id=%s)", j.ID())
+		}
+
+		return nil, status.Errorf(codes.Internal, "resume failed (id=%s): %v", j.ID(), err)
+	}
+
+	fmt.Printf("job resumed (id=%s, owner=%s)\n", j.ID(), identity.Name)
+
+	return &taskerpb.ResumeJobResponse{Job: convertJob(j)}, nil
+}
+
+func (s *Server) UpdateJobLimits(
+	ctx context.Context,
+	req *taskerpb.UpdateJobLimitsRequest,
+) (*taskerpb.UpdateJobLimitsResponse, error) {
+	identity, err := rpc.IdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := s.convertUpdateLimits(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The update alone may pass while clashing with limits it leaves unchanged
+	if err := checkLimits(mergeLimits(j.Limits(), limits)); err != nil {
+		return nil, err
+	}
+
+	if _, err := j.UpdateLimits(limits); err != nil {
+		if errors.Is(err, job.ErrNotRunning) {
+			return nil, status.Errorf(codes.FailedPrecondition, "job is not running (id=%s)", j.ID())
+		}
+
+		return nil, status.Errorf(codes.Internal, "update limits failed (id=%s): %v", j.ID(), err)
+	}
+
+	fmt.Printf("job limits updated (id=%s, owner=%s)\n", j.ID(), identity.Name)
+
+	return &taskerpb.UpdateJobLimitsResponse{Job: convertJob(j)}, nil
+}
+
+func (s *Server) GetJobStats(ctx context.Context, req *taskerpb.GetJobStatsRequest) (*taskerpb.GetJobStatsResponse, error) {
+	identity, err := rpc.IdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := j.Stats()
+	if err != nil {
+		return nil, statsError(j, err)
+	}
+
+	return &taskerpb.GetJobStatsResponse{Stats: convertStats(stats)}, nil
+}
+
+func (s *Server) WatchJobStats(
+	req *taskerpb.WatchJobStatsRequest,
+	stream grpc.ServerStreamingServer[taskerpb.WatchJobStatsResponse],
+) error {
+	identity, err := rpc.IdentityFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	j, err := s.findJob(identity, req.Id)
+	if err != nil {
+		return err
+	}
+
+	interval := defaultStatsInterval
+	if req.IntervalMs != nil {
+		interval = time.Duration(*req.IntervalMs) * time.Millisecond
+		if interval < minStatsInterval {
+			return status.Errorf(codes.InvalidArgument, "interval must be at least %s", minStatsInterval)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := j.Stats()
+		if err != nil {
+			// The job exited without a final sample
+			if errors.Is(err, job.ErrNotRunning) {
+				return nil
+			}
+
+			return statsError(j, err)
+		}
+
+		if err := stream.Send(&taskerpb.WatchJobStatsResponse{Stats: convertStats(stats)}); err != nil {
+			return err
+		}
+
+		// The stream ends with the final sample
+		if stats.Final {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-j.Done():
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// statsError converts a job.Stats error to a gRPC status.
+func statsError(j *job.Job, err error) error {
+	if errors.Is(err, job.ErrNotRunning) {
+		return status.Errorf(codes.FailedPrecondition, "job is not running (id=%s)", j.ID())
+	}
+
+	return status.Errorf(codes.Internal, "read stats failed (id=%s): %v", j.ID(), err)
+}
+
+// findJob returns the job with the given ID if the identity can manage it.
+func (s *Server) findJob(identity rpc.Identity, id string) (*job.Job, error) {
+	s.mu.RLock()
+	j, exists := s.mu.jobs[id]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "job not found (id=%s)", id)
+	}
+
+	if err := checkJobAccess(identity, j.Owner()); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
 // checkJobAccess verifies the identity can manage the given job.
 //
 // Admins can manage any job; users can only manage their own.
@@ -164,43 +566,573 @@ func checkJobAccess(id rpc.Identity, owner string) error {
 	return nil
 }
 
-// convertJob builds a proto Job from a job.Job.
-func convertJob(j *job.Job) *taskerpb.Job {
-	var phase taskerpb.JobPhase
+// matchJob reports whether a job passes the filters of a ListJobsRequest.
+func matchJob(req *taskerpb.ListJobsRequest, j listedJob) bool {
+	if req.Owner != nil && *req.Owner != j.Owner() {
+		return false
+	}
+
+	if req.Phase != taskerpb.JobPhase_JOB_PHASE_UNSPECIFIED && req.Phase != convertPhase(j.Phase()) {
+		return false
+	}
+
+	if req.Command != "" {
+		commandLine := strings.Join(append([]string{j.Command()}, j.Args()...), " ")
+		if !strings.Contains(commandLine, req.Command) {
+			return false
+		}
+	}
+
+	created := j.Created()
+	if req.CreatedAfter != nil && created.Before(req.CreatedAfter.AsTime()) {
+		return false
+	}
+
+	if req.CreatedBefore != nil && !created.Before(req.CreatedBefore.AsTime()) {
+		return false
+	}
+
+	return true
+}
+
+// listedJob is the part of a job that ListJobs filters and pages on.
+type listedJob interface {
+	ID() string
+	Owner() string
+	Phase() job.Phase
+	Command() string
+	Args() []string
+	Created() time.Time
+}
+
+// pageJobs returns the page of jobs matching req that comes after pageToken, oldest first, and the token of the next
+// page (empty when no more jobs match).
+func pageJobs[J listedJob](req *taskerpb.ListJobsRequest, jobs []J, pageToken string, pageSize int) ([]J, string) {
+	// IDs are UUIDv7 so sorting by ID sorts by creation time
+	jobs = slices.Clone(jobs)
+	slices.SortFunc(jobs, func(a, b J) int { return strings.Compare(a.ID(), b.ID()) })
+
+	var page []J
+	for _, j := range jobs {
+		if j.ID() <= pageToken || !matchJob(req, j) {
+			continue
+		}
+
+		if len(page) == pageSize {
+			return page, page[len(page)-1].ID()
+		}
+
+		page = append(page, j)
+	}
+
+	return page, ""
+}
+
+// parsePageToken validates a page token, which is the ID of the last job returned, and returns it in the canonical
+// form job IDs are compared in.
+func parsePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	id, err := uuid.Parse(token)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+
+	return id.String(), nil
+}
+
+// outputDir returns the directory jobs spill their output to (empty keeps output in memory).
+func (s *Server) outputDir() string {
+	if s.cfg.DataDir == "" {
+		return ""
+	}
+
+	return filepath.Join(s.cfg.DataDir, "output")
+}
+
+// convertLimits builds job.Limits from proto ResourceLimits and validates them.
+func (s *Server) convertLimits(limitspb *taskerpb.ResourceLimits) (job.Limits, error) {
+	limits := job.Limits{}
+	if limitspb == nil {
+		return limits, nil
+	}
+
+	if limitspb.Cpu != nil && !checkCores(*limitspb.Cpu, minCPU) {
+		return limits, status.Errorf(codes.InvalidArgument, "cpu must be between %.2f and %d cores", minCPU, maxCPU)
+	}
+
+	if limitspb.Memory != nil && *limitspb.Memory == 0 {
+		return limits, status.Error(codes.InvalidArgument, "memory must be at least 1 MB")
+	}
+
+	limits.CPU = limitspb.Cpu
+	limits.Memory = limitspb.Memory
+
+	for i, iopb := range limitspb.Io {
+		if iopb.Device == "" {
+			return limits, status.Error(codes.InvalidArgument, "device is required when IO limits are set")
+		}
+
+		if slices.ContainsFunc(limitspb.Io[:i], func(other *taskerpb.IOLimits) bool { return other.Device == iopb.Device }) {
+			return limits, status.Errorf(codes.InvalidArgument, "device is listed more than once (device=%s)", iopb.Device)
+		}
+
+		if iopb.Weight != nil && (*iopb.Weight == 0 || *iopb.Weight > maxIOWeight) {
+			return limits, status.Errorf(codes.InvalidArgument, "io weight must be between 1 and %d (device=%s)", maxIOWeight, iopb.Device)
+		}
+
+		if iopb.Latency != nil && *iopb.Latency == 0 {
+			return limits, status.Errorf(codes.InvalidArgument, "io latency must be at least 1 usec (device=%s)", iopb.Device)
+		}
+
+		limits.IO = append(limits.IO, job.IOLimits{
+			Device:    iopb.Device,
+			Read:      iopb.Read,
+			Write:     iopb.Write,
+			ReadIOPS:  iopb.ReadIops,
+			WriteIOPS: iopb.WriteIops,
+			Weight:    iopb.Weight,
+			Latency:   iopb.Latency,
+		})
+	}
+
+	if limitspb.Pids != nil {
+		if *limitspb.Pids == 0 || *limitspb.Pids > s.cfg.PIDs {
+			return limits, status.Errorf(codes.InvalidArgument, "pids must be between 1 and %d", s.cfg.PIDs)
+		}
+
+		limits.PIDs = limitspb.Pids
+	}
+
+	limits.OOMGroup = limitspb.OomGroup
+
+	if limitspb.MemoryHigh != nil && *limitspb.MemoryHigh == 0 {
+		return limits, status.Error(codes.InvalidArgument, "memory high must be at least 1 MB")
+	}
+
+	limits.MemoryHigh = limitspb.MemoryHigh
+	limits.MemoryLow = limitspb.MemoryLow
+	limits.MemoryMin = limitspb.MemoryMin
+	limits.Swap = limitspb.Swap
+
+	if limitspb.CpuWeight != nil && (*limitspb.CpuWeight == 0 || *limitspb.CpuWeight > maxCPUWeight) {
+		return limits, status.Errorf(codes.InvalidArgument, "cpu weight must be between 1 and %d", maxCPUWeight)
+	}
+
+	if limitspb.CpuBurst != nil && !checkCores(*limitspb.CpuBurst, 0) {
+		return limits, status.Errorf(codes.InvalidArgument, "cpu burst must be between 0 and %d cores", maxCPU)
+	}
+
+	if limitspb.CpusetCpus != nil {
+		if err := checkCPUList(*limitspb.CpusetCpus); err != nil {
+			return limits, status.Errorf(codes.InvalidArgument, "invalid cpuset cpus (cpus=%s): %v", *limitspb.CpusetCpus, err)
+		}
+	}
+
+	if limitspb.CpusetMems != nil {
+		if err := checkCPUList(*limitspb.CpusetMems); err != nil {
+			return limits, status.Errorf(codes.InvalidArgument, "invalid cpuset mems (mems=%s): %v", *limitspb.CpusetMems, err)
+		}
+	}
+
+	limits.CPUWeight = limitspb.CpuWeight
+	limits.CPUBurst = limitspb.CpuBurst
+	limits.CPUs = limitspb.CpusetCpus
+	limits.Mems = limitspb.CpusetMems
+
+	if err := checkLimits(limits); err != nil {
+		return limits, err
+	}
+
+	return limits, nil
+}
+
+// checkCores reports whether cores is a number between minimum and maxCPU.
+func checkCores(cores, minimum float32) bool {
+	if math.IsNaN(float64(cores)) || math.IsInf(float64(cores), 0) {
+		return false
+	}
+
+	return cores >= minimum && cores <= maxCPU
+}
+
+// checkCPUList verifies a cpuset list (e.g. 0-3,8) is well formed, leaving whether the CPUs or nodes exist to the kernel.
+func checkCPUList(list string) error {
+	if list == "" {
+		return errors.New("list is empty")
+	}
+
+	for item := range strings.SplitSeq(list, ",") {
+		first, last, isRange := strings.Cut(item, "-")
+
+		start, err := strconv.ParseUint(first, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid entry (entry=%s)", item)
+		}
+
+		if !isRange {
+			continue
+		}
+
+		end, err := strconv.ParseUint(last, 10, 32)
+		if err != nil || end < start {
+			return fmt.Errorf("invalid range (range=%s)", item)
+		}
+	}
+
+	return nil
+}
+
+// checkLimits verifies limits that depend on each other.
+func checkLimits(limits job.Limits) error {
+	if limits.CPU != nil && limits.CPUBurst != nil && *limits.CPUBurst > *limits.CPU {
+		return status.Errorf(codes.InvalidArgument, "cpu burst cannot exceed cpu (burst=%.2f, cpu=%.2f)", *limits.CPUBurst, *limits.CPU)
+	}
+
+	if limits.Memory == nil {
+		return nil
+	}
+
+	memory := *limits.Memory
+	if limits.MemoryHigh != nil && *limits.MemoryHigh > memory {
+		return status.Errorf(codes.InvalidArgument, "memory high cannot exceed memory (high=%d, memory=%d)", *limits.MemoryHigh, memory)
+	}
+
+	if limits.MemoryLow != nil && *limits.MemoryLow > memory {
+		return status.Errorf(codes.InvalidArgument, "memory low cannot exceed memory (low=%d, memory=%d)", *limits.MemoryLow, memory)
+	}
+
+	if limits.MemoryMin != nil && *limits.MemoryMin > memory {
+		return status.Errorf(codes.InvalidArgument, "memory min cannot exceed memory (min=%d, memory=%d)", *limits.MemoryMin, memory)
+	}
+
+	return nil
+}
+
+// convertUpdateLimits validates the limits of an update, with clear_io setting an empty list of io limits so the
+// current ones are lifted.
+func (s *Server) convertUpdateLimits(req *taskerpb.UpdateJobLimitsRequest) (job.Limits, error) {
+	if req.Limits == nil && !req.ClearIo {
+		return job.Limits{}, status.Error(codes.InvalidArgument, "limits are required")
+	}
+
+	if req.ClearIo && len(req.Limits.GetIo()) > 0 {
+		return job.Limits{}, status.Error(codes.InvalidArgument, "io limits cannot be set and cleared together")
+	}
+
+	limits, err := s.convertLimits(req.Limits)
+	if err != nil {
+		return limits, err
+	}
+
+	if req.ClearIo {
+		limits.IO = []job.IOLimits{}
+	}
+
+	return limits, nil
+}
+
+// mergeLimits overlays the limits checked by checkLimits in an update onto the current limits.
+func mergeLimits(current, update job.Limits) job.Limits {
+	merged := current
+	if update.CPU != nil {
+		merged.CPU = update.CPU
+	}
+
+	if update.CPUBurst != nil {
+		merged.CPUBurst = update.CPUBurst
+	}
+
+	if update.Memory != nil {
+		merged.Memory = update.Memory
+	}
+
+	if update.MemoryHigh != nil {
+		merged.MemoryHigh = update.MemoryHigh
+	}
+
+	if update.MemoryLow != nil {
+		merged.MemoryLow = update.MemoryLow
+	}
+
+	if update.MemoryMin != nil {
+		merged.MemoryMin = update.MemoryMin
+	}
+
+	return merged
+}
+
+// parseSignal parses a signal name (SIGINT or INT) or number.
+func parseSignal(name string) (unix.Signal, error) {
+	if num, err := strconv.Atoi(name); err == nil {
+		// Numbers beyond the named signals are the real-time signals
+		if num < 1 || num > maxSignal {
+			return 0, status.Errorf(codes.InvalidArgument, "invalid signal (signal=%s)", name)
+		}
+
+		return unix.Signal(num), nil
+	}
+
+	upper := strings.ToUpper(name)
+	if !strings.HasPrefix(upper, "SIG") {
+		upper = "SIG" + upper
+	}
+
+	sig := unix.SignalNum(upper)
+	if sig == 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "unknown signal (signal=%s)", name)
+	}
+
+	return sig, nil
+}
+
+// signalName returns a signal's name or its number if it has no name (real-time signals).
+func signalName(sig unix.Signal) string {
+	if name := unix.SignalName(sig); name != "" {
+		return name
+	}
 
-	switch j.Phase() {
+	return strconv.Itoa(int(sig))
+}
+
+// convertPhase builds a proto JobPhase from a job.Phase.
+func convertPhase(phase job.Phase) taskerpb.JobPhase {
+	switch phase {
 	case job.PhaseRunning:
-		phase = taskerpb.JobPhase_JOB_PHASE_RUNNING
+		return taskerpb.JobPhase_JOB_PHASE_RUNNING
 	case job.PhaseStopped:
-		phase = taskerpb.JobPhase_JOB_PHASE_STOPPED
+		return taskerpb.JobPhase_JOB_PHASE_STOPPED
 	case job.PhaseCompleted:
-		phase = taskerpb.JobPhase_JOB_PHASE_COMPLETED
+		return taskerpb.JobPhase_JOB_PHASE_COMPLETED
+	case job.PhaseTimedOut:
+		return taskerpb.JobPhase_JOB_PHASE_TIMED_OUT
+	case job.PhasePaused:
+		return taskerpb.JobPhase_JOB_PHASE_PAUSED
+	default:
+		return taskerpb.JobPhase_JOB_PHASE_UNSPECIFIED
+	}
+}
+
+// convertStats builds proto JobStats from job.Stats.
+func convertStats(stats job.Stats) *taskerpb.JobStats {
+	statspb := &taskerpb.JobStats{
+		Time: timestamppb.New(stats.Time),
+		Cpu: &taskerpb.CPUStats{
+			UsageUsec:     stats.CPU.UsageUsec,
+			UserUsec:      stats.CPU.UserUsec,
+			SystemUsec:    stats.CPU.SystemUsec,
+			NrPeriods:     stats.CPU.NrPeriods,
+			NrThrottled:   stats.CPU.NrThrottled,
+			ThrottledUsec: stats.CPU.ThrottledUsec,
+		},
+		Memory: &taskerpb.MemoryStats{
+			Current:    stats.Memory.Current,
+			Peak:       stats.Memory.Peak,
+			Anon:       stats.Memory.Anon,
+			File:       stats.Memory.File,
+			Kernel:     stats.Memory.Kernel,
+			Shmem:      stats.Memory.Shmem,
+			Sock:       stats.Memory.Sock,
+			Pgfault:    stats.Memory.PgFault,
+			Pgmajfault: stats.Memory.PgMajFault,
+			Oom:        stats.Memory.OOM,
+			OomKill:    stats.Memory.OOMKill,
+		},
+		Pids:  &taskerpb.PIDsStats{Current: stats.PIDs.Current},
+		Final: stats.Final,
+	}
+
+	for _, device := range stats.IO {
+		statspb.Io = append(statspb.Io, &taskerpb.IOStats{
+			Device: device.Device,
+			Rbytes: device.RBytes,
+			Wbytes: device.WBytes,
+			Rios:   device.RIOs,
+			Wios:   device.WIOs,
+			Dbytes: device.DBytes,
+			Dios:   device.DIOs,
+		})
+	}
+
+	return statspb
+}
+
+// convertExitReason builds a proto ExitReason from a job.ExitReason.
+func convertExitReason(reason job.ExitReason) taskerpb.ExitReason {
+	switch reason {
+	case job.ExitReasonExited:
+		return taskerpb.ExitReason_EXIT_REASON_EXITED
+	case job.ExitReasonSignaled:
+		return taskerpb.ExitReason_EXIT_REASON_SIGNALED
+	case job.ExitReasonOOMKilled:
+		return taskerpb.ExitReason_EXIT_REASON_OOM_KILLED
+	default:
+		return taskerpb.ExitReason_EXIT_REASON_UNSPECIFIED
+	}
+}
+
+// parseReadOptions converts an AttachJobRequest to the options of a job output reader.
+func parseReadOptions(req *taskerpb.AttachJobRequest) (job.ReadOptions, error) {
+	stream, err := parseOutputStream(req.Stream)
+	if err != nil {
+		return job.ReadOptions{}, err
+	}
+
+	starts := 0
+	for _, set := range []bool{req.Since != nil, req.Offset > 0, req.TailBytes > 0, req.TailLines > 0} {
+		if set {
+			starts++
+		}
+	}
+
+	if starts > 1 {
+		return job.ReadOptions{}, status.Error(
+			codes.InvalidArgument,
+			"only one of since, offset, tail bytes and tail lines can be set",
+		)
+	}
+
+	for _, value := range []uint64{req.Offset, req.TailBytes, req.TailLines} {
+		if value > math.MaxInt64 {
+			return job.ReadOptions{}, status.Errorf(codes.InvalidArgument, "start is too large (value=%d)", value)
+		}
+	}
+
+	opts := job.ReadOptions{
+		Stream:    stream,
+		Offset:    int64(req.Offset),
+		TailBytes: int64(req.TailBytes),
+		TailLines: int64(req.TailLines),
+		NoFollow:  req.NoFollow,
 	}
 
+	if req.Since != nil {
+		opts.Since = req.Since.AsTime()
+	}
+
+	return opts, nil
+}
+
+// parseOutputStream converts a proto OutputStream to a job.Stream (unspecified selects both).
+func parseOutputStream(stream taskerpb.OutputStream) (job.Stream, error) {
+	switch stream {
+	case taskerpb.OutputStream_OUTPUT_STREAM_UNSPECIFIED:
+		return job.StreamUnknown, nil
+	case taskerpb.OutputStream_OUTPUT_STREAM_STDOUT:
+		return job.StreamStdout, nil
+	case taskerpb.OutputStream_OUTPUT_STREAM_STDERR:
+		return job.StreamStderr, nil
+	default:
+		return job.StreamUnknown, status.Errorf(codes.InvalidArgument, "unknown output stream (stream=%d)", stream)
+	}
+}
+
+// convertOutputStream converts a job.Stream to a proto OutputStream.
+func convertOutputStream(stream job.Stream) taskerpb.OutputStream {
+	switch stream {
+	case job.StreamStdout:
+		return taskerpb.OutputStream_OUTPUT_STREAM_STDOUT
+	case job.StreamStderr:
+		return taskerpb.OutputStream_OUTPUT_STREAM_STDERR
+	default:
+		return taskerpb.OutputStream_OUTPUT_STREAM_UNSPECIFIED
+	}
+}
+
+// convertJob builds a proto Job from a job.Job.
+func convertJob(j *job.Job) *taskerpb.Job {
 	limits := j.Limits()
 
 	jobpb := &taskerpb.Job{
-		Id:      j.ID(),
-		Owner:   j.Owner(),
-		Command: j.Command(),
-		Args:    j.Args(),
-		Phase:   phase,
+		Id:        j.ID(),
+		Owner:     j.Owner(),
+		Command:   j.Command(),
+		Args:      j.Args(),
+		Phase:     convertPhase(j.Phase()),
+		CreatedAt: timestamppb.New(j.Created()),
+		StartedAt: timestamppb.New(j.Started()),
+	}
+
+	if finished := j.Finished(); !finished.IsZero() {
+		jobpb.FinishedAt = timestamppb.New(finished)
 	}
 
-	if limits.CPU != nil || limits.Memory != nil || limits.IO != nil {
-		jobpb.Limits = &taskerpb.ResourceLimits{
-			Cpu:    limits.CPU,
-			Memory: limits.Memory,
+	for _, t := range j.Transitions() {
+		jobpb.Transitions = append(jobpb.Transitions, &taskerpb.PhaseTransition{
+			From: convertPhase(t.From),
+			To:   convertPhase(t.To),
+			Time: timestamppb.New(t.Time),
+			By:   t.By,
+		})
+	}
+
+	if exit := j.Exit(); exit != nil {
+		jobpb.Exit = &taskerpb.ExitStatus{
+			Reason:     convertExitReason(exit.Reason),
+			Code:       int32(exit.Code),
+			CoreDumped: exit.CoreDumped,
 		}
 
-		if limits.IO != nil {
-			jobpb.Limits.Io = &taskerpb.IOLimits{
-				Device: limits.IO.Device,
-				Read:   limits.IO.Read,
-				Write:  limits.IO.Write,
-			}
+		if exit.Signal != 0 {
+			jobpb.Exit.Signal = signalName(exit.Signal)
 		}
 	}
 
+	if err := j.Err(); err != nil {
+		jobpb.Error = err.Error()
+	}
+
+	jobpb.PidsMaxEvents = j.PIDsMaxEvents()
+	jobpb.Tty = j.TTY()
+	jobpb.Workdir = j.Dir()
+	jobpb.Timeout = uint32(j.Timeout() / time.Second)
+	jobpb.StopSignal = signalName(j.StopSignal())
+	jobpb.StopGrace = uint32(j.StopGrace() / time.Second)
+	jobpb.OutputDropped = uint64(j.OutputDropped())
+
+	if usage := j.Usage(); usage != nil {
+		jobpb.Usage = convertStats(*usage)
+	}
+
+	if cred := j.Credential(); cred != nil {
+		jobpb.Uid = cred.Uid
+		jobpb.Gid = cred.Gid
+		jobpb.Groups = cred.Groups
+	}
+
+	limitspb := &taskerpb.ResourceLimits{
+		Cpu:        limits.CPU,
+		Memory:     limits.Memory,
+		Pids:       limits.PIDs,
+		OomGroup:   limits.OOMGroup,
+		MemoryHigh: limits.MemoryHigh,
+		MemoryLow:  limits.MemoryLow,
+		MemoryMin:  limits.MemoryMin,
+		Swap:       limits.Swap,
+		CpuWeight:  limits.CPUWeight,
+		CpuBurst:   limits.CPUBurst,
+		CpusetCpus: limits.CPUs,
+		CpusetMems: limits.Mems,
+	}
+
+	for _, limit := range limits.IO {
+		limitspb.Io = append(limitspb.Io, &taskerpb.IOLimits{
+			Device:    limit.Device,
+			Read:      limit.Read,
+			Write:     limit.Write,
+			ReadIops:  limit.ReadIOPS,
+			WriteIops: limit.WriteIOPS,
+			Weight:    limit.Weight,
+			Latency:   limit.Latency,
+		})
+	}
+
+	// Jobs without limits leave them unset
+	if proto.Size(limitspb) > 0 {
+		jobpb.Limits = limitspb
+	}
+
 	return jobpb
 }
@@ -1,11 +1,21 @@
 package server
 
 import (
+	"math"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
+	"github.com/wolves-fc/tasker/lib/job"
 	"github.com/wolves-fc/tasker/lib/rpc"
 	"github.com/wolves-fc/tasker/lib/tls"
 )
@@ -36,3 +46,401 @@ func TestCheckJobAccess(t *testing.T) {
 		})
 	}
 }
+
+// fakeJob is a listedJob for testing ListJobs filters and pages.
+type fakeJob struct {
+	id      string
+	owner   string
+	phase   job.Phase
+	command string
+	args    []string
+	created time.Time
+}
+
+func (j fakeJob) ID() string         { return j.id }
+func (j fakeJob) Owner() string      { return j.owner }
+func (j fakeJob) Phase() job.Phase   { return j.phase }
+func (j fakeJob) Command() string    { return j.command }
+func (j fakeJob) Args() []string     { return j.args }
+func (j fakeJob) Created() time.Time { return j.created }
+
+func TestMatchJob(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	j := fakeJob{
+		id:      "job",
+		owner:   "wolf",
+		command: "python3",
+		args:    []string{"./tools/jobs/counter.py"},
+		phase:   job.PhaseRunning,
+		created: created,
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  *taskerpb.ListJobsRequest
+		want bool
+	}{
+		{"no_filters", &taskerpb.ListJobsRequest{}, true},
+		{"owner_match", &taskerpb.ListJobsRequest{Owner: proto.String("wolf")}, true},
+		{"owner_mismatch", &taskerpb.ListJobsRequest{Owner: proto.String("wolfjr")}, false},
+		{"phase_match", &taskerpb.ListJobsRequest{Phase: taskerpb.JobPhase_JOB_PHASE_RUNNING}, true},
+		{"phase_mismatch", &taskerpb.ListJobsRequest{Phase: taskerpb.JobPhase_JOB_PHASE_STOPPED}, false},
+		{"command_match", &taskerpb.ListJobsRequest{Command: "python3"}, true},
+		{"command_args_match", &taskerpb.ListJobsRequest{Command: "3 ./tools"}, true},
+		{"command_mismatch", &taskerpb.ListJobsRequest{Command: "sleep"}, false},
+		{"created_after_match", &taskerpb.ListJobsRequest{CreatedAfter: timestamppb.New(created)}, true},
+		{
+			"created_after_mismatch",
+			&taskerpb.ListJobsRequest{CreatedAfter: timestamppb.New(created.Add(time.Second))},
+			false,
+		},
+		{
+			"created_before_match",
+			&taskerpb.ListJobsRequest{CreatedBefore: timestamppb.New(created.Add(time.Second))},
+			true,
+		},
+		{"created_before_mismatch", &taskerpb.ListJobsRequest{CreatedBefore: timestamppb.New(created)}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := matchJob(tc.req, j); got != tc.want {
+				t.Errorf("matchJob (got=%v, want=%v)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPageJobs(t *testing.T) {
+	t.Parallel()
+
+	// IDs sort in creation order like UUIDv7s
+	jobs := []fakeJob{
+		{id: "0003", owner: "wolf", command: "sleep"},
+		{id: "0001", owner: "wolf", command: "sleep"},
+		{id: "0005", owner: "wolfjr", command: "sleep"},
+		{id: "0002", owner: "wolf", command: "echo"},
+		{id: "0004", owner: "wolf", command: "sleep"},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		req       *taskerpb.ListJobsRequest
+		pageToken string
+		pageSize  int
+		want      []string
+		wantNext  string
+	}{
+		{"all", &taskerpb.ListJobsRequest{}, "", 10, []string{"0001", "0002", "0003", "0004", "0005"}, ""},
+		{"first_page", &taskerpb.ListJobsRequest{}, "", 2, []string{"0001", "0002"}, "0002"},
+		{"next_page", &taskerpb.ListJobsRequest{}, "0002", 2, []string{"0003", "0004"}, "0004"},
+		{"last_page", &taskerpb.ListJobsRequest{}, "0004", 2, []string{"0005"}, ""},
+		{"exact_page", &taskerpb.ListJobsRequest{}, "0003", 2, []string{"0004", "0005"}, ""},
+		{"filtered_page", &taskerpb.ListJobsRequest{Command: "sleep"}, "", 2, []string{"0001", "0003"}, "0003"},
+		// No more jobs match the filter so there's no next page
+		{
+			"filtered_last_page",
+			&taskerpb.ListJobsRequest{Owner: proto.String("wolf")},
+			"0002",
+			2,
+			[]string{"0003", "0004"},
+			"",
+		},
+		{"past_end", &taskerpb.ListJobsRequest{}, "0005", 2, nil, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			page, next := pageJobs(tc.req, jobs, tc.pageToken, tc.pageSize)
+
+			var got []string
+			for _, j := range page {
+				got = append(got, j.ID())
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("page (got=%v, want=%v)", got, tc.want)
+			}
+
+			if next != tc.wantNext {
+				t.Fatalf("next page token (got=%q, want=%q)", next, tc.wantNext)
+			}
+		})
+	}
+}
+
+func TestParsePageToken(t *testing.T) {
+	t.Parallel()
+
+	const id = "01912345-6789-7abc-8def-0123456789ab"
+
+	for _, tc := range []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", false},
+		{"canonical", id, id, false},
+		{"uppercase", strings.ToUpper(id), id, false},
+		{"braces", "{" + id + "}", id, false},
+		{"urn", "urn:uuid:" + id, id, false},
+		{"invalid", "job", "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parsePageToken(tc.token)
+			if tc.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("code (got=%v, want=%v)", status.Code(err), codes.InvalidArgument)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parsePageToken (got=%v, want=nil)", err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("token (got=%q, want=%q)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvertLimits(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{cfg: Config{PIDs: 1000}}
+
+	for _, tc := range []struct {
+		name   string
+		limits *taskerpb.ResourceLimits
+		want   codes.Code
+	}{
+		{"nil", nil, codes.OK},
+		{"cpu_memory", &taskerpb.ResourceLimits{Cpu: proto.Float32(0.5), Memory: proto.Uint32(512)}, codes.OK},
+		{"cpu_min", &taskerpb.ResourceLimits{Cpu: proto.Float32(0.01)}, codes.OK},
+		{"cpu_zero", &taskerpb.ResourceLimits{Cpu: proto.Float32(0)}, codes.InvalidArgument},
+		{"cpu_negative", &taskerpb.ResourceLimits{Cpu: proto.Float32(-1)}, codes.InvalidArgument},
+		{"cpu_too_small", &taskerpb.ResourceLimits{Cpu: proto.Float32(0.001)}, codes.InvalidArgument},
+		{"cpu_nan", &taskerpb.ResourceLimits{Cpu: proto.Float32(float32(math.NaN()))}, codes.InvalidArgument},
+		{"cpu_inf", &taskerpb.ResourceLimits{Cpu: proto.Float32(float32(math.Inf(1)))}, codes.InvalidArgument},
+		{"cpu_max", &taskerpb.ResourceLimits{Cpu: proto.Float32(maxCPU)}, codes.OK},
+		{"cpu_over_max", &taskerpb.ResourceLimits{Cpu: proto.Float32(maxCPU * 2)}, codes.InvalidArgument},
+		{"memory_zero", &taskerpb.ResourceLimits{Memory: proto.Uint32(0)}, codes.InvalidArgument},
+		{"memory_high", &taskerpb.ResourceLimits{Memory: proto.Uint32(512), MemoryHigh: proto.Uint32(384)}, codes.OK},
+		{"memory_high_equal", &taskerpb.ResourceLimits{Memory: proto.Uint32(512), MemoryHigh: proto.Uint32(512)}, codes.OK},
+		{"memory_high_over_max", &taskerpb.ResourceLimits{Memory: proto.Uint32(512), MemoryHigh: proto.Uint32(513)}, codes.InvalidArgument},
+		{"memory_high_no_max", &taskerpb.ResourceLimits{MemoryHigh: proto.Uint32(1024)}, codes.OK},
+		{"memory_high_zero", &taskerpb.ResourceLimits{MemoryHigh: proto.Uint32(0)}, codes.InvalidArgument},
+		{"memory_low_min", &taskerpb.ResourceLimits{Memory: proto.Uint32(512), MemoryLow: proto.Uint32(256), MemoryMin: proto.Uint32(128)}, codes.OK},
+		{"memory_low_over_max", &taskerpb.ResourceLimits{Memory: proto.Uint32(512), MemoryLow: proto.Uint32(1024)}, codes.InvalidArgument},
+		{"memory_min_over_max", &taskerpb.ResourceLimits{Memory: proto.Uint32(512), MemoryMin: proto.Uint32(1024)}, codes.InvalidArgument},
+		{"swap_zero", &taskerpb.ResourceLimits{Swap: proto.Uint32(0)}, codes.OK},
+		{"cpu_weight", &taskerpb.ResourceLimits{CpuWeight: proto.Uint32(200)}, codes.OK},
+		{"cpu_weight_zero", &taskerpb.ResourceLimits{CpuWeight: proto.Uint32(0)}, codes.InvalidArgument},
+		{"cpu_weight_over_max", &taskerpb.ResourceLimits{CpuWeight: proto.Uint32(10001)}, codes.InvalidArgument},
+		{"cpu_burst", &taskerpb.ResourceLimits{Cpu: proto.Float32(1), CpuBurst: proto.Float32(0.5)}, codes.OK},
+		{"cpu_burst_over_cpu", &taskerpb.ResourceLimits{Cpu: proto.Float32(1), CpuBurst: proto.Float32(2)}, codes.InvalidArgument},
+		{"cpu_burst_negative", &taskerpb.ResourceLimits{CpuBurst: proto.Float32(-1)}, codes.InvalidArgument},
+		{"cpu_burst_nan", &taskerpb.ResourceLimits{CpuBurst: proto.Float32(float32(math.NaN()))}, codes.InvalidArgument},
+		{"cpu_burst_inf", &taskerpb.ResourceLimits{CpuBurst: proto.Float32(float32(math.Inf(1)))}, codes.InvalidArgument},
+		{"cpu_burst_over_max", &taskerpb.ResourceLimits{CpuBurst: proto.Float32(maxCPU * 2)}, codes.InvalidArgument},
+		{"cpuset", &taskerpb.ResourceLimits{CpusetCpus: proto.String("0-3,8"), CpusetMems: proto.String("0")}, codes.OK},
+		{"cpuset_cpus_invalid", &taskerpb.ResourceLimits{CpusetCpus: proto.String("0-")}, codes.InvalidArgument},
+		{"cpuset_mems_empty", &taskerpb.ResourceLimits{CpusetMems: proto.String("")}, codes.InvalidArgument},
+		{"io_device", &taskerpb.ResourceLimits{Io: []*taskerpb.IOLimits{{Device: "/dev/sda"}}}, codes.OK},
+		{"io_no_device", &taskerpb.ResourceLimits{Io: []*taskerpb.IOLimits{{Read: proto.Uint32(100)}}}, codes.InvalidArgument},
+		{"io_devices", &taskerpb.ResourceLimits{Io: []*taskerpb.IOLimits{
+			{Device: "/dev/sda", Read: proto.Uint32(100), ReadIops: proto.Uint32(1000)},
+			{Device: "/dev/sdb", Weight: proto.Uint32(200), Latency: proto.Uint32(5000)},
+		}}, codes.OK},
+		{"io_duplicate_device", &taskerpb.ResourceLimits{Io: []*taskerpb.IOLimits{{Device: "/dev/sda"}, {Device: "/dev/sda"}}}, codes.InvalidArgument},
+		{"io_weight_zero", &taskerpb.ResourceLimits{Io: []*taskerpb.IOLimits{{Device: "/dev/sda", Weight: proto.Uint32(0)}}}, codes.InvalidArgument},
+		{"io_weight_over_max", &taskerpb.ResourceLimits{Io: []*taskerpb.IOLimits{{Device: "/dev/sda", Weight: proto.Uint32(10001)}}}, codes.InvalidArgument},
+		{"io_latency_zero", &taskerpb.ResourceLimits{Io: []*taskerpb.IOLimits{{Device: "/dev/sda", Latency: proto.Uint32(0)}}}, codes.InvalidArgument},
+		{"pids", &taskerpb.ResourceLimits{Pids: proto.Uint32(100)}, codes.OK},
+		{"pids_server_max", &taskerpb.ResourceLimits{Pids: proto.Uint32(1000)}, codes.OK},
+		{"pids_zero", &taskerpb.ResourceLimits{Pids: proto.Uint32(0)}, codes.InvalidArgument},
+		{"pids_over_server_max", &taskerpb.ResourceLimits{Pids: proto.Uint32(1001)}, codes.InvalidArgument},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := s.convertLimits(tc.limits)
+			if got := status.Code(err); got != tc.want {
+				t.Errorf("code (got=%v, want=%v)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvertUpdateLimits(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{cfg: Config{PIDs: 1000}}
+	io := []*taskerpb.IOLimits{{Device: "/dev/sda", Read: proto.Uint32(100)}}
+
+	for _, tc := range []struct {
+		name   string
+		req    *taskerpb.UpdateJobLimitsRequest
+		want   codes.Code
+		wantIO []job.IOLimits
+	}{
+		{"no_limits", &taskerpb.UpdateJobLimitsRequest{}, codes.InvalidArgument, nil},
+		{"cpu", &taskerpb.UpdateJobLimitsRequest{Limits: &taskerpb.ResourceLimits{Cpu: proto.Float32(1)}}, codes.OK, nil},
+		{"io", &taskerpb.UpdateJobLimitsRequest{Limits: &taskerpb.ResourceLimits{Io: io}}, codes.OK, []job.IOLimits{
+			{Device: "/dev/sda", Read: proto.Uint32(100)},
+		}},
+		{"clear_io", &taskerpb.UpdateJobLimitsRequest{ClearIo: true}, codes.OK, []job.IOLimits{}},
+		{"clear_io_cpu", &taskerpb.UpdateJobLimitsRequest{
+			Limits:  &taskerpb.ResourceLimits{Cpu: proto.Float32(1)},
+			ClearIo: true,
+		}, codes.OK, []job.IOLimits{}},
+		{"clear_io_with_io", &taskerpb.UpdateJobLimitsRequest{
+			Limits:  &taskerpb.ResourceLimits{Io: io},
+			ClearIo: true,
+		}, codes.InvalidArgument, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			limits, err := s.convertUpdateLimits(tc.req)
+			if got := status.Code(err); got != tc.want {
+				t.Fatalf("code (got=%v, want=%v)", got, tc.want)
+			}
+
+			// A nil list leaves the io limits as they are while an empty one lifts them
+			if !reflect.DeepEqual(limits.IO, tc.wantIO) {
+				t.Fatalf("io limits (got=%+v, want=%+v)", limits.IO, tc.wantIO)
+			}
+		})
+	}
+}
+
+func TestCheckCPUList(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		list    string
+		wantErr bool
+	}{
+		{"single", "0", false},
+		{"range", "0-3", false},
+		{"mixed", "0-3,8,10-11", false},
+		{"same_range", "2-2", false},
+		{"empty", "", true},
+		{"trailing_comma", "0,", true},
+		{"open_range", "0-", true},
+		{"reversed_range", "3-0", true},
+		{"negative", "-1", true},
+		{"name", "all", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkCPUList(tc.list)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("checkCPUList (got=%v, wantErr=%t)", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSignal(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		signal  string
+		want    unix.Signal
+		wantErr bool
+	}{
+		{"full_name", "SIGINT", unix.SIGINT, false},
+		{"short_name", "QUIT", unix.SIGQUIT, false},
+		{"lowercase", "hup", unix.SIGHUP, false},
+		{"number", "15", unix.SIGTERM, false},
+		{"realtime", "40", unix.Signal(40), false},
+		{"zero", "0", 0, true},
+		{"too_high", "65", 0, true},
+		{"negative", "-9", 0, true},
+		{"unknown", "SIGWOLF", 0, true},
+		{"empty", "", 0, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseSignal(tc.signal)
+			if tc.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("code (got=%v, want=%v)", status.Code(err), codes.InvalidArgument)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseSignal (got=%v, want=nil)", err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("signal (got=%v, want=%v)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseReadOptions(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 2, 14, 9, 30, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name    string
+		req     *taskerpb.AttachJobRequest
+		want    job.ReadOptions
+		wantErr bool
+	}{
+		{"default", &taskerpb.AttachJobRequest{}, job.ReadOptions{}, false},
+		{
+			"stream_offset",
+			&taskerpb.AttachJobRequest{Stream: taskerpb.OutputStream_OUTPUT_STREAM_STDERR, Offset: 42, NoFollow: true},
+			job.ReadOptions{Stream: job.StreamStderr, Offset: 42, NoFollow: true},
+			false,
+		},
+		{"since", &taskerpb.AttachJobRequest{Since: timestamppb.New(since)}, job.ReadOptions{Since: since}, false},
+		{"tail_bytes", &taskerpb.AttachJobRequest{TailBytes: 1024}, job.ReadOptions{TailBytes: 1024}, false},
+		{"tail_lines", &taskerpb.AttachJobRequest{TailLines: 10}, job.ReadOptions{TailLines: 10}, false},
+		{"offset_and_tail", &taskerpb.AttachJobRequest{Offset: 42, TailLines: 10}, job.ReadOptions{}, true},
+		{"since_and_tail", &taskerpb.AttachJobRequest{Since: timestamppb.New(since), TailBytes: 1}, job.ReadOptions{}, true},
+		{"offset_too_large", &taskerpb.AttachJobRequest{Offset: math.MaxUint64}, job.ReadOptions{}, true},
+		{"unknown_stream", &taskerpb.AttachJobRequest{Stream: 9}, job.ReadOptions{}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseReadOptions(tc.req)
+			if tc.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("code (got=%v, want=%v)", status.Code(err), codes.InvalidArgument)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseReadOptions (got=%v, want=nil)", err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("options (got=%+v, want=%+v)", got, tc.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,8 @@ package server
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
-	"time"
 
 	taskerpb "github.com/wolves-fc/tasker/gen/proto/tasker"
 	"github.com/wolves-fc/tasker/lib/job"
@@ -14,10 +14,30 @@ import (
 // Compile time verification that Server implements taskerpb.TaskerServiceServer.
 var _ taskerpb.TaskerServiceServer = (*Server)(nil)
 
+// Config holds the server settings.
+type Config struct {
+	// CertDir is the certificates directory.
+	CertDir string
+	// Name is the server name (cert name).
+	Name string
+	// Addr is the listen address.
+	Addr string
+	// PIDs is the default and max number of processes per job.
+	PIDs uint32
+	// Users maps identities to the Linux accounts their jobs run as.
+	Users UserMap
+	// OutputLimit is the max bytes of output kept per job, dropping the oldest bytes over it (0 keeps everything).
+	OutputLimit int64
+	// DataDir is where job output is spilled to disk (empty keeps output in memory).
+	DataDir string
+}
+
 // Server manages jobs on a single machine.
 type Server struct {
 	taskerpb.UnimplementedTaskerServiceServer
 
+	cfg Config
+
 	mu struct {
 		sync.RWMutex
 		jobs map[string]*job.Job
@@ -25,31 +45,36 @@ type Server struct {
 }
 
 // New initializes cgroups, serves gRPC requests, and owns the lifecycle of all jobs.
-func New(ctx context.Context, certDir, name, addr string) error {
-	s := &Server{}
+func New(ctx context.Context, cfg Config) error {
+	s := &Server{cfg: cfg}
 	s.mu.jobs = make(map[string]*job.Job)
 
 	if err := job.Init(); err != nil {
 		return fmt.Errorf("init cgroup: %w", err)
 	}
 
-	if err := rpc.Serve(ctx, s, certDir, name, addr); err != nil {
+	// Jobs aren't loaded back so output left by a previous run can never be read
+	if err := s.removeOutput(); err != nil {
 		return err
 	}
 
-	// Give each job 2 seconds to gracefully stop
-	stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
+	if err := rpc.Serve(ctx, s, cfg.CertDir, cfg.Name, cfg.Addr); err != nil {
+		return err
+	}
 
 	s.mu.RLock()
 	var wg sync.WaitGroup
 	for _, j := range s.mu.jobs {
 		wg.Go(func() {
-			if j.Phase() != job.PhaseRunning {
+			if !j.Active() {
 				return
 			}
 
-			if err := j.Stop(stopCtx); err != nil {
+			// Give each job its own grace period to gracefully stop
+			stopCtx, cancel := context.WithTimeout(context.Background(), j.StopGrace())
+			defer cancel()
+
+			if err := j.Stop(stopCtx, 0, "server"); err != nil {
 				fmt.Printf("job force killed (id=%s): %v\n", j.ID(), err)
 			} else {
 				fmt.Printf("job stopped (id=%s)\n", j.ID())
@@ -60,7 +85,25 @@ func New(ctx context.Context, certDir, name, addr string) error {
 
 	wg.Wait()
 
+	if err := s.removeOutput(); err != nil {
+		fmt.Printf("%v\n", err)
+	}
+
 	fmt.Println("server stopped")
 
 	return nil
 }
+
+// removeOutput removes the output every job spilled to disk.
+func (s *Server) removeOutput() error {
+	dir := s.outputDir()
+	if dir == "" {
+		return nil
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("remove output directory (dir=%s): %w", dir, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"fmt"
+	"os/user"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/wolves-fc/tasker/lib/rpc"
+	"github.com/wolves-fc/tasker/lib/tls"
+)
+
+// Account is the Linux account a job runs as.
+type Account struct {
+	UID    uint32
+	GID    uint32
+	Groups []uint32
+}
+
+// credential returns the process credential for the account.
+//
+// Supplementary groups are always set so the job never keeps the server's groups.
+func (a Account) credential() *syscall.Credential {
+	return &syscall.Credential{
+		Uid:    a.UID,
+		Gid:    a.GID,
+		Groups: slices.Clone(a.Groups),
+	}
+}
+
+// UserMap maps Tasker identities to the Linux accounts their jobs run as.
+type UserMap struct {
+	// Users maps identity names to accounts.
+	Users map[string]Account
+	// Roles maps identity roles to accounts for names without their own mapping.
+	Roles map[tls.Role]Account
+	// Default is used when neither the name nor the role is mapped (optional).
+	Default *Account
+}
+
+// Lookup returns the account for an identity.
+//
+// A name mapping wins over a role mapping which wins over the default.
+func (m UserMap) Lookup(identity rpc.Identity) (Account, bool) {
+	if account, exists := m.Users[identity.Name]; exists {
+		return account, true
+	}
+
+	if account, exists := m.Roles[identity.Role]; exists {
+		return account, true
+	}
+
+	if m.Default != nil {
+		return *m.Default, true
+	}
+
+	return Account{}, false
+}
+
+// ParseUserMap builds a UserMap from run-as entries and an optional default account.
+//
+// Each entry is <name>=<account> or role:<role>=<account>. See ParseAccount for the account format.
+func ParseUserMap(entries []string, defaultAccount string) (UserMap, error) {
+	m := UserMap{
+		Users: make(map[string]Account),
+		Roles: make(map[tls.Role]Account),
+	}
+
+	for _, entry := range entries {
+		key, spec, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			return UserMap{}, fmt.Errorf("invalid run-as (entry=%s): want <name>=<account> or role:<role>=<account>", entry)
+		}
+
+		account, err := ParseAccount(spec)
+		if err != nil {
+			return UserMap{}, fmt.Errorf("invalid run-as (entry=%s): %w", entry, err)
+		}
+
+		if role, isRole := strings.CutPrefix(key, "role:"); isRole {
+			if tls.Role(role) != tls.RoleAdmin && tls.Role(role) != tls.RoleUser {
+				return UserMap{}, fmt.Errorf("invalid run-as (entry=%s): unknown role %s", entry, role)
+			}
+
+			m.Roles[tls.Role(role)] = account
+			continue
+		}
+
+		m.Users[key] = account
+	}
+
+	if defaultAccount != "" {
+		account, err := ParseAccount(defaultAccount)
+		if err != nil {
+			return UserMap{}, fmt.Errorf("invalid default run-as: %w", err)
+		}
+
+		m.Default = &account
+	}
+
+	return m, nil
+}
+
+// ParseAccount parses a Linux account given as a username or uid:gid[:group,...].
+//
+// Usernames are resolved with their primary and supplementary groups.
+func ParseAccount(spec string) (Account, error) {
+	if spec == "" {
+		return Account{}, fmt.Errorf("account is required")
+	}
+
+	if !strings.Contains(spec, ":") {
+		return lookupAccount(spec)
+	}
+
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 {
+		return Account{}, fmt.Errorf("invalid account (account=%s): want uid:gid[:group,...]", spec)
+	}
+
+	uid, err := parseID(parts[0])
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid uid (account=%s): %w", spec, err)
+	}
+
+	if len(parts) < 2 {
+		return Account{}, fmt.Errorf("invalid account (account=%s): gid is required", spec)
+	}
+
+	gid, err := parseID(parts[1])
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid gid (account=%s): %w", spec, err)
+	}
+
+	account := Account{UID: uid, GID: gid}
+	if len(parts) == 3 && parts[2] != "" {
+		for group := range strings.SplitSeq(parts[2], ",") {
+			id, err := parseID(group)
+			if err != nil {
+				return Account{}, fmt.Errorf("invalid group (account=%s): %w", spec, err)
+			}
+
+			account.Groups = append(account.Groups, id)
+		}
+	}
+
+	return account, nil
+}
+
+// lookupAccount resolves a username to an account.
+func lookupAccount(name string) (Account, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		return Account{}, fmt.Errorf("lookup user: %w", err)
+	}
+
+	uid, err := parseID(u.Uid)
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid uid (user=%s): %w", name, err)
+	}
+
+	gid, err := parseID(u.Gid)
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid gid (user=%s): %w", name, err)
+	}
+
+	groupIDs, err := u.GroupIds()
+	if err != nil {
+		return Account{}, fmt.Errorf("lookup groups (user=%s): %w", name, err)
+	}
+
+	account := Account{UID: uid, GID: gid}
+	for _, groupID := range groupIDs {
+		id, err := parseID(groupID)
+		if err != nil {
+			return Account{}, fmt.Errorf("invalid group (user=%s): %w", name, err)
+		}
+
+		// The primary group is already the gid
+		if id != gid {
+			account.Groups = append(account.Groups, id)
+		}
+	}
+
+	return account, nil
+}
+
+// parseID parses a numeric uid or gid.
+func parseID(value string) (uint32, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(id), nil
+}
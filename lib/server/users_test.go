@@ -0,0 +1,127 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wolves-fc/tasker/lib/rpc"
+	"github.com/wolves-fc/tasker/lib/tls"
+)
+
+func TestParseAccount(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		spec    string
+		want    Account
+		wantErr bool
+	}{
+		{"uid_gid", "1000:1000", Account{UID: 1000, GID: 1000}, false},
+		{"groups", "1000:1000:27,100", Account{UID: 1000, GID: 1000, Groups: []uint32{27, 100}}, false},
+		{"empty_groups", "1000:1000:", Account{UID: 1000, GID: 1000}, false},
+		{"root", "root", Account{UID: 0, GID: 0}, false},
+		{"empty", "", Account{}, true},
+		{"missing_gid", "1000:", Account{}, true},
+		{"bad_uid", "wolf:1000", Account{}, true},
+		{"bad_group", "1000:1000:wheel", Account{}, true},
+		{"too_many_parts", "1000:1000:1:2", Account{}, true},
+		{"unknown_user", "tasker-no-such-user", Account{}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseAccount(tc.spec)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseAccount (got=%+v, want=error)", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseAccount (got=%v, want=nil)", err)
+			}
+
+			// Root's supplementary groups depend on the host
+			if tc.spec == "root" {
+				got.Groups = nil
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("account (got=%+v, want=%+v)", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseUserMap(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name           string
+		entries        []string
+		defaultAccount string
+		wantErr        bool
+	}{
+		{"none", nil, "", false},
+		{"name", []string{"wolf=1000:1000"}, "", false},
+		{"role", []string{"role:user=1001:1001"}, "65534:65534", false},
+		{"missing_account", []string{"wolf"}, "", true},
+		{"missing_name", []string{"=1000:1000"}, "", true},
+		{"unknown_role", []string{"role:server=1000:1000"}, "", true},
+		{"bad_account", []string{"wolf=1000"}, "", true},
+		{"bad_default", nil, "1000", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseUserMap(tc.entries, tc.defaultAccount)
+			if got := err != nil; got != tc.wantErr {
+				t.Fatalf("ParseUserMap error (got=%v, wantErr=%v)", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserMap_Lookup(t *testing.T) {
+	t.Parallel()
+
+	users, err := ParseUserMap([]string{"wolf=1000:1000", "role:user=1001:1001"}, "")
+	if err != nil {
+		t.Fatalf("ParseUserMap: %v", err)
+	}
+
+	withDefault, err := ParseUserMap([]string{"wolf=1000:1000"}, "65534:65534")
+	if err != nil {
+		t.Fatalf("ParseUserMap: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		users   UserMap
+		id      rpc.Identity
+		wantUID uint32
+		wantOK  bool
+	}{
+		{"name", users, rpc.Identity{Name: "wolf", Role: tls.RoleUser}, 1000, true},
+		{"role", users, rpc.Identity{Name: "wolfjr", Role: tls.RoleUser}, 1001, true},
+		{"unmapped", users, rpc.Identity{Name: "wolfsr", Role: tls.RoleAdmin}, 0, false},
+		{"default", withDefault, rpc.Identity{Name: "wolfsr", Role: tls.RoleAdmin}, 65534, true},
+		{"empty", UserMap{}, rpc.Identity{Name: "wolf", Role: tls.RoleAdmin}, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tc.users.Lookup(tc.id)
+			if ok != tc.wantOK {
+				t.Fatalf("found (got=%v, want=%v)", ok, tc.wantOK)
+			}
+
+			if got.UID != tc.wantUID {
+				t.Fatalf("uid (got=%d, want=%d)", got.UID, tc.wantUID)
+			}
+		})
+	}
+}